@@ -1,39 +1,125 @@
 package main
 
 import (
+	"context"
 	"log"
 	"net/http"
 	"os"
+	"time"
+	_ "time/tzdata"
 
 	"reservation-system/internal/api/handler"
 	"reservation-system/internal/api/middleware"
+	"reservation-system/internal/domain"
 	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/infrastructure/jwt"
+	"reservation-system/internal/job"
+	"reservation-system/internal/usecase"
 )
 
 func main() {
 	if err := db.InitDatabase(); err != nil {
 		log.Fatal("Failed to initialize database:", err)
 	}
+	jwt.UseRevocationList(db.NewRevokedTokenRepository())
+
+	keys, err := jwt.LoadKeySetFromEnv()
+	if err != nil {
+		log.Fatal("Failed to load JWT keys:", err)
+	}
+	if keys != nil {
+		jwt.UseKeySet(keys)
+		log.Printf("Signing access tokens with key %s", keys.SigningKeyID())
+	}
 
 	userHandler := handler.NewUserHandler()
 	reservationHandler := handler.NewReservationHandler()
 	authHandler := handler.NewAuthHandler()
+	resourceHandler := handler.NewResourceHandler()
+	availabilityHandler := handler.NewAvailabilityHandler()
+	seriesHandler := handler.NewReservationSeriesHandler()
+	waitlistHandler := handler.NewWaitlistHandler()
+	blackoutHandler := handler.NewBlackoutHandler()
+	organizationHandler := handler.NewOrganizationHandler()
+	passwordResetHandler := handler.NewPasswordResetHandler()
+	emailVerificationHandler := handler.NewEmailVerificationHandler()
+	mfaHandler := handler.NewMFAHandler()
 
 	router := handler.NewRouter()
 
+	adminOnly := middleware.RequireRole(domain.RoleAdmin)
+	staffOnly := middleware.RequireRole(domain.RoleAdmin, domain.RoleStaff)
+
+	router.GET("/.well-known/jwks.json", middleware.CORSMiddleware(authHandler.JWKS))
 	router.POST("/api/auth/register", middleware.CORSMiddleware(authHandler.Register))
 	router.POST("/api/auth/login", middleware.CORSMiddleware(authHandler.Login))
 	router.POST("/api/auth/validate", middleware.CORSMiddleware(authHandler.ValidateToken))
+	router.POST("/api/auth/refresh", middleware.CORSMiddleware(authHandler.Refresh))
+	router.POST("/api/auth/logout", middleware.CORSMiddleware(middleware.AuthMiddleware(authHandler.Logout)))
+	router.POST("/api/auth/password/forgot", middleware.CORSMiddleware(passwordResetHandler.ForgotPassword))
+	router.POST("/api/auth/password/reset", middleware.CORSMiddleware(passwordResetHandler.ResetPassword))
+	router.POST("/api/auth/verify", middleware.CORSMiddleware(emailVerificationHandler.VerifyEmail))
+	router.POST("/api/auth/verify/resend", middleware.CORSMiddleware(middleware.AuthMiddleware(emailVerificationHandler.ResendVerification)))
+	router.POST("/api/auth/mfa/verify", middleware.CORSMiddleware(mfaHandler.VerifyChallenge))
+	router.POST("/api/auth/mfa/totp/enroll", middleware.CORSMiddleware(middleware.AuthMiddleware(mfaHandler.EnrollTOTP)))
+	router.POST("/api/auth/mfa/totp/confirm", middleware.CORSMiddleware(middleware.AuthMiddleware(mfaHandler.ConfirmTOTP)))
+	router.POST("/api/auth/mfa/totp/disable", middleware.CORSMiddleware(middleware.AuthMiddleware(mfaHandler.DisableTOTP)))
 
 	router.POST("/api/users", middleware.CORSMiddleware(userHandler.CreateUser))
-	router.GET("/api/users", middleware.CORSMiddleware(userHandler.GetUser))
+	router.GET("/api/users", middleware.CORSMiddleware(middleware.AuthMiddleware(userHandler.GetUser)))
 	router.POST("/api/users/login", middleware.CORSMiddleware(userHandler.Login))
+	router.PUT("/api/users/role", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(userHandler.UpdateRole))))
+	router.PUT("/api/users/unlock", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(userHandler.UnlockUser))))
+
+	router.POST("/api/organizations", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(organizationHandler.CreateOrganization))))
+	router.GET("/api/organizations", middleware.CORSMiddleware(middleware.AuthMiddleware(organizationHandler.ListOrganizations)))
+	router.POST("/api/organizations/members", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(organizationHandler.AddMember))))
+	router.DELETE("/api/organizations/members", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(organizationHandler.RemoveMember))))
+
+	router.POST("/api/resources", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.CreateResource))))
+	router.GET("/api/resources", middleware.CORSMiddleware(middleware.AuthMiddleware(resourceHandler.GetResource)))
+	router.PUT("/api/resources", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.UpdateResource))))
+	router.DELETE("/api/resources", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.DeleteResource))))
+	router.PUT("/api/resources/schedule", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.UpdateSchedule))))
+	router.PUT("/api/resources/cancellation-policy", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.UpdateCancellationPolicy))))
+	router.PUT("/api/resources/approval", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.UpdateApproval))))
+	router.PUT("/api/resources/staff", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(resourceHandler.UpdateStaff))))
+
+	router.POST("/api/blackouts", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(blackoutHandler.CreateBlackout))))
+	router.GET("/api/blackouts", middleware.CORSMiddleware(middleware.AuthMiddleware(blackoutHandler.GetBlackout)))
+	router.PUT("/api/blackouts", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(blackoutHandler.UpdateBlackout))))
+	router.DELETE("/api/blackouts", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(blackoutHandler.DeleteBlackout))))
+	router.POST("/api/blackouts/import", middleware.CORSMiddleware(middleware.AuthMiddleware(adminOnly(blackoutHandler.ImportHolidays))))
+
+	router.GET("/api/availability", middleware.CORSMiddleware(middleware.AuthMiddleware(availabilityHandler.GetAvailability)))
 
 	router.POST("/api/reservations", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.CreateReservation)))
 	router.GET("/api/reservations", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.GetReservation)))
 	router.GET("/api/reservations/user", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.GetUserReservations)))
+	router.GET("/api/reservations/resource", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.GetResourceReservations))))
 	router.POST("/api/reservations/confirm", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.ConfirmReservation)))
+	router.GET("/api/reservations/approvals", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.GetApprovalQueue))))
+	router.POST("/api/reservations/:id/approve", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.ApproveReservation))))
+	router.POST("/api/reservations/:id/reject", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.RejectReservation))))
 	router.DELETE("/api/reservations", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.CancelReservation)))
+	router.PUT("/api/reservations/:id", middleware.CORSMiddleware(middleware.AuthMiddleware(reservationHandler.RescheduleReservation)))
+	router.POST("/api/reservations/:id/check-in", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.CheckInReservation))))
+	router.POST("/api/reservations/:id/complete", middleware.CORSMiddleware(middleware.AuthMiddleware(staffOnly(reservationHandler.CompleteReservation))))
+
+	router.POST("/api/reservations/series", middleware.CORSMiddleware(middleware.AuthMiddleware(seriesHandler.CreateSeries)))
+	router.GET("/api/reservations/series", middleware.CORSMiddleware(middleware.AuthMiddleware(seriesHandler.GetSeries)))
+	router.DELETE("/api/reservations/series", middleware.CORSMiddleware(middleware.AuthMiddleware(seriesHandler.CancelSeries)))
+
+	router.GET("/api/waitlist", middleware.CORSMiddleware(middleware.AuthMiddleware(waitlistHandler.GetEntry)))
+	router.DELETE("/api/waitlist", middleware.CORSMiddleware(middleware.AuthMiddleware(waitlistHandler.LeaveWaitlist)))
+
+	ctx := context.Background()
+	reservationUseCase := usecase.NewReservationUseCase()
+	go job.Run(ctx, "expire-pending-reservations", time.Minute, reservationUseCase.ExpirePendingReservations)
+	go job.Run(ctx, "mark-no-show-reservations", time.Minute, reservationUseCase.MarkNoShows)
+	authUseCase := usecase.NewAuthUseCase()
+	go job.Run(ctx, "purge-expired-tokens", time.Hour, authUseCase.PurgeExpiredTokens)
+	go job.Run(ctx, "purge-login-failures", time.Hour, authUseCase.PurgeLoginFailures)
 
 	port := os.Getenv("PORT")
 	if port == "" {
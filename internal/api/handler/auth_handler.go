@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"reservation-system/internal/domain"
@@ -70,13 +71,19 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req.IPAddress = clientIP(r)
 	resp, err := h.authUseCase.Authenticate(&req)
 	if err != nil {
-		if err == domain.ErrInvalidCredentials {
+		switch err {
+		case domain.ErrInvalidCredentials:
 			response.Unauthorized(w, "Invalid credentials")
-			return
+		case domain.ErrAccountLocked, domain.ErrTooManyLoginAttempts:
+			response.Error(w, http.StatusTooManyRequests, err.Error())
+		case domain.ErrNotOrganizationMember:
+			response.Forbidden(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to authenticate")
 		}
-		response.InternalServerError(w, "Failed to authenticate")
 		return
 	}
 
@@ -109,3 +116,65 @@ func (h *AuthHandler) ValidateToken(w http.ResponseWriter, r *http.Request) {
 
 	response.Success(w, claims)
 }
+
+func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	var req usecase.RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("refresh_token", req.RefreshToken)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	resp, err := h.authUseCase.Refresh(&req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidRefreshToken, domain.ErrRefreshTokenReused:
+			response.Unauthorized(w, err.Error())
+		case domain.ErrAccountLocked:
+			response.Error(w, http.StatusTooManyRequests, err.Error())
+		case domain.ErrNotOrganizationMember:
+			response.Forbidden(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to refresh token")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	// 本文は省略可能（リフレッシュトークンを渡すとそのファミリーも失効させる）
+	var req usecase.LogoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.authUseCase.Logout(&req, principal)
+	if err != nil {
+		response.InternalServerError(w, "Failed to logout")
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Logged out"})
+}
+
+// JWKS 他のサービスがアクセストークンを検証するための公開鍵
+// JWKS の形式で返すため、共通のレスポンス形式には包まない
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.WriteJSON(w, http.StatusOK, h.authUseCase.PublicKeys())
+}
@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+)
+
+type AvailabilityHandler struct {
+	availabilityUseCase *usecase.AvailabilityUseCase
+}
+
+func NewAvailabilityHandler() *AvailabilityHandler {
+	return &AvailabilityHandler{
+		availabilityUseCase: usecase.NewAvailabilityUseCase(),
+	}
+}
+
+func (h *AvailabilityHandler) GetAvailability(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	resourceIDStr := query.Get("resource")
+	fromStr := query.Get("from")
+	toStr := query.Get("to")
+
+	if resourceIDStr == "" || fromStr == "" || toStr == "" {
+		response.BadRequest(w, "Resource, from and to are required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	from, err := time.Parse("2006-01-02", fromStr)
+	if err != nil {
+		response.BadRequest(w, "Invalid from date format. Use YYYY-MM-DD")
+		return
+	}
+
+	to, err := time.Parse("2006-01-02", toStr)
+	if err != nil {
+		response.BadRequest(w, "Invalid to date format. Use YYYY-MM-DD")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	days, err := h.availabilityUseCase.GetAvailability(principal.OrganizationID, uint(resourceID), from, to)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidDateRange:
+			response.BadRequest(w, "Invalid date range. The range must not exceed 31 days")
+		default:
+			response.InternalServerError(w, "Failed to get availability")
+		}
+		return
+	}
+
+	response.Success(w, days)
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type BlackoutHandler struct {
+	blackoutUseCase *usecase.BlackoutUseCase
+}
+
+func NewBlackoutHandler() *BlackoutHandler {
+	return &BlackoutHandler{
+		blackoutUseCase: usecase.NewBlackoutUseCase(),
+	}
+}
+
+type blackoutRequest struct {
+	ResourceID *uint  `json:"resource_id"`
+	Date       string `json:"date"`
+	Reason     string `json:"reason"`
+}
+
+// decodeBlackoutRequest リクエストボディを休業日リクエストに変換（失敗時はレスポンス済み）
+func decodeBlackoutRequest(w http.ResponseWriter, r *http.Request) (*usecase.BlackoutRequest, bool) {
+	var req blackoutRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return nil, false
+	}
+
+	v := validator.NewValidator()
+	v.Required("date", req.Date).
+		Required("reason", req.Reason)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return nil, false
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		response.BadRequest(w, "Invalid date format. Use YYYY-MM-DD")
+		return nil, false
+	}
+
+	return &usecase.BlackoutRequest{
+		ResourceID: req.ResourceID,
+		Date:       date,
+		Reason:     req.Reason,
+	}, true
+}
+
+func (h *BlackoutHandler) CreateBlackout(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeBlackoutRequest(w, r)
+	if !ok {
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	blackout, err := h.blackoutUseCase.CreateBlackout(principal.OrganizationID, req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidBlackoutDate, domain.ErrInvalidResource:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to create blackout date")
+		}
+		return
+	}
+
+	response.Created(w, blackout)
+}
+
+func (h *BlackoutHandler) GetBlackout(w http.ResponseWriter, r *http.Request) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	blackoutIDStr := query.Get("id")
+	if blackoutIDStr == "" {
+		h.listBlackouts(w, principal.OrganizationID, query.Get("resource_id"))
+		return
+	}
+
+	blackoutID, err := strconv.ParseUint(blackoutIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid blackout ID")
+		return
+	}
+
+	blackout, err := h.blackoutUseCase.GetBlackout(principal.OrganizationID, uint(blackoutID))
+	if err != nil {
+		if err == domain.ErrBlackoutNotFound {
+			response.NotFound(w, "Blackout date not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to get blackout date")
+		return
+	}
+
+	response.Success(w, blackout)
+}
+
+func (h *BlackoutHandler) listBlackouts(w http.ResponseWriter, organizationID uint, resourceIDStr string) {
+	resourceID, ok := parseOptionalResourceID(w, resourceIDStr)
+	if !ok {
+		return
+	}
+
+	blackouts, err := h.blackoutUseCase.ListBlackouts(organizationID, resourceID)
+	if err != nil {
+		response.InternalServerError(w, "Failed to get blackout dates")
+		return
+	}
+
+	response.Success(w, blackouts)
+}
+
+func (h *BlackoutHandler) UpdateBlackout(w http.ResponseWriter, r *http.Request) {
+	blackoutIDStr := r.URL.Query().Get("id")
+	if blackoutIDStr == "" {
+		response.BadRequest(w, "Blackout ID is required")
+		return
+	}
+
+	blackoutID, err := strconv.ParseUint(blackoutIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid blackout ID")
+		return
+	}
+
+	req, ok := decodeBlackoutRequest(w, r)
+	if !ok {
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	blackout, err := h.blackoutUseCase.UpdateBlackout(principal.OrganizationID, uint(blackoutID), req)
+	if err != nil {
+		switch err {
+		case domain.ErrBlackoutNotFound:
+			response.NotFound(w, "Blackout date not found")
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidBlackoutDate, domain.ErrInvalidResource:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update blackout date")
+		}
+		return
+	}
+
+	response.Success(w, blackout)
+}
+
+func (h *BlackoutHandler) DeleteBlackout(w http.ResponseWriter, r *http.Request) {
+	blackoutIDStr := r.URL.Query().Get("id")
+	if blackoutIDStr == "" {
+		response.BadRequest(w, "Blackout ID is required")
+		return
+	}
+
+	blackoutID, err := strconv.ParseUint(blackoutIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid blackout ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err = h.blackoutUseCase.DeleteBlackout(principal.OrganizationID, uint(blackoutID))
+	if err != nil {
+		if err == domain.ErrBlackoutNotFound {
+			response.NotFound(w, "Blackout date not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to delete blackout date")
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Blackout date deleted"})
+}
+
+// ImportHolidays リクエストボディのCSV・ICSファイルから祝日を取り込む
+func (h *BlackoutHandler) ImportHolidays(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	format := query.Get("format")
+	if format != usecase.HolidayFormatCSV && format != usecase.HolidayFormatICS {
+		response.BadRequest(w, "Format must be csv or ics")
+		return
+	}
+
+	resourceID, ok := parseOptionalResourceID(w, query.Get("resource_id"))
+	if !ok {
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.blackoutUseCase.ImportHolidays(principal.OrganizationID, format, r.Body, resourceID)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidHolidayFile:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to import holidays")
+		}
+		return
+	}
+
+	response.Created(w, resp)
+}
+
+// parseOptionalResourceID 空文字なら nil を返す（不正な値の場合はレスポンス済み）
+func parseOptionalResourceID(w http.ResponseWriter, value string) (*uint, bool) {
+	if value == "" {
+		return nil, true
+	}
+
+	resourceID, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return nil, false
+	}
+
+	id := uint(resourceID)
+	return &id, true
+}
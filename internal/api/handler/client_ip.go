@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// clientIP リクエストの接続元 IP アドレス
+// 環境変数 TRUST_PROXY_HEADERS が true の場合は、リバースプロキシが X-Forwarded-For の末尾に追加したアドレスを使う
+// （それより前の値はクライアントが自由に送れるため使わない）
+func clientIP(r *http.Request) string {
+	if os.Getenv("TRUST_PROXY_HEADERS") == "true" {
+		if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+			addresses := strings.Split(forwarded, ",")
+			if ip := strings.TrimSpace(addresses[len(addresses)-1]); ip != "" {
+				return ip
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
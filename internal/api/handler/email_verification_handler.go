@@ -0,0 +1,73 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type EmailVerificationHandler struct {
+	emailVerificationUseCase *usecase.EmailVerificationUseCase
+}
+
+func NewEmailVerificationHandler() *EmailVerificationHandler {
+	return &EmailVerificationHandler{
+		emailVerificationUseCase: usecase.NewEmailVerificationUseCase(),
+	}
+}
+
+func (h *EmailVerificationHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	var req usecase.VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("token", req.Token)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	user, err := h.emailVerificationUseCase.Verify(&req)
+	if err != nil {
+		if err == domain.ErrInvalidVerificationToken {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.InternalServerError(w, "Failed to verify email")
+		return
+	}
+
+	response.Success(w, user)
+}
+
+func (h *EmailVerificationHandler) ResendVerification(w http.ResponseWriter, r *http.Request) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.emailVerificationUseCase.Resend(principal)
+	if err != nil {
+		switch err {
+		case domain.ErrEmailAlreadyVerified:
+			response.Conflict(w, err.Error())
+		case domain.ErrVerificationThrottled:
+			response.Error(w, http.StatusTooManyRequests, err.Error())
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		default:
+			response.InternalServerError(w, "Failed to resend verification email")
+		}
+		return
+	}
+
+	response.Accepted(w, map[string]string{"message": "Verification email has been sent"})
+}
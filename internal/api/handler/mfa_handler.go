@@ -0,0 +1,153 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type MFAHandler struct {
+	mfaUseCase *usecase.MFAUseCase
+}
+
+func NewMFAHandler() *MFAHandler {
+	return &MFAHandler{
+		mfaUseCase: usecase.NewMFAUseCase(),
+	}
+}
+
+func (h *MFAHandler) VerifyChallenge(w http.ResponseWriter, r *http.Request) {
+	var req usecase.VerifyMFARequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("mfa_token", req.MFAToken).
+		Required("code", req.Code)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	req.IPAddress = clientIP(r)
+	resp, err := h.mfaUseCase.VerifyChallenge(&req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidMFAChallenge, domain.ErrInvalidMFACode:
+			response.Unauthorized(w, err.Error())
+		case domain.ErrAccountLocked, domain.ErrTooManyLoginAttempts:
+			response.Error(w, http.StatusTooManyRequests, err.Error())
+		case domain.ErrNotOrganizationMember:
+			response.Forbidden(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to verify authentication code")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *MFAHandler) EnrollTOTP(w http.ResponseWriter, r *http.Request) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.mfaUseCase.EnrollTOTP(principal)
+	if err != nil {
+		switch err {
+		case domain.ErrTOTPAlreadyEnabled:
+			response.Conflict(w, err.Error())
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		default:
+			response.InternalServerError(w, "Failed to start two-factor enrollment")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *MFAHandler) ConfirmTOTP(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeTOTPCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.mfaUseCase.ConfirmTOTP(principal, req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidMFACode, domain.ErrTOTPNotEnrolled:
+			response.BadRequest(w, err.Error())
+		case domain.ErrTOTPAlreadyEnabled:
+			response.Conflict(w, err.Error())
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		default:
+			response.InternalServerError(w, "Failed to enable two-factor authentication")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *MFAHandler) DisableTOTP(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeTOTPCodeRequest(w, r)
+	if !ok {
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.mfaUseCase.DisableTOTP(principal, req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidMFACode, domain.ErrTOTPNotEnabled:
+			response.BadRequest(w, err.Error())
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		default:
+			response.InternalServerError(w, "Failed to disable two-factor authentication")
+		}
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Two-factor authentication disabled"})
+}
+
+// decodeTOTPCodeRequest リクエストボディを認証コードのリクエストに変換（失敗時はレスポンス済み）
+func decodeTOTPCodeRequest(w http.ResponseWriter, r *http.Request) (*usecase.TOTPCodeRequest, bool) {
+	var req usecase.TOTPCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return nil, false
+	}
+
+	v := validator.NewValidator()
+	v.Required("code", req.Code)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return nil, false
+	}
+
+	return &req, true
+}
@@ -0,0 +1,138 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type OrganizationHandler struct {
+	organizationUseCase *usecase.OrganizationUseCase
+}
+
+func NewOrganizationHandler() *OrganizationHandler {
+	return &OrganizationHandler{
+		organizationUseCase: usecase.NewOrganizationUseCase(),
+	}
+}
+
+func (h *OrganizationHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req usecase.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("name", req.Name).
+		Required("slug", req.Slug)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	organization, err := h.organizationUseCase.CreateOrganization(&req, principal)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidOrganization:
+			response.BadRequest(w, err.Error())
+		case domain.ErrOrganizationExists:
+			response.Conflict(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to create organization")
+		}
+		return
+	}
+
+	response.Created(w, organization)
+}
+
+func (h *OrganizationHandler) ListOrganizations(w http.ResponseWriter, r *http.Request) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	organizations, err := h.organizationUseCase.ListOrganizations(principal)
+	if err != nil {
+		response.InternalServerError(w, "Failed to get organizations")
+		return
+	}
+
+	response.Success(w, organizations)
+}
+
+func (h *OrganizationHandler) AddMember(w http.ResponseWriter, r *http.Request) {
+	var req usecase.AddMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("user_id", strconv.Itoa(int(req.UserID)))
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	membership, err := h.organizationUseCase.AddMember(&req, principal)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		case domain.ErrInvalidMembership:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to add member")
+		}
+		return
+	}
+
+	response.Created(w, membership)
+}
+
+func (h *OrganizationHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	userID, ok := queryUserID(w, r)
+	if !ok {
+		return
+	}
+	if userID == 0 {
+		response.BadRequest(w, "User ID is required")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err := h.organizationUseCase.RemoveMember(userID, principal)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFound(w, "User not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to remove member")
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Member removed"})
+}
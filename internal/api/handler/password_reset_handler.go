@@ -0,0 +1,77 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type PasswordResetHandler struct {
+	passwordResetUseCase *usecase.PasswordResetUseCase
+}
+
+func NewPasswordResetHandler() *PasswordResetHandler {
+	return &PasswordResetHandler{
+		passwordResetUseCase: usecase.NewPasswordResetUseCase(),
+	}
+}
+
+func (h *PasswordResetHandler) ForgotPassword(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("email", req.Email).
+		Email("email", req.Email)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	err := h.passwordResetUseCase.RequestReset(&req)
+	if err != nil {
+		response.InternalServerError(w, "Failed to request password reset")
+		return
+	}
+
+	// 登録の有無にかかわらず同じレスポンスを返す
+	response.Accepted(w, map[string]string{"message": "If the email is registered, a password reset email has been sent"})
+}
+
+func (h *PasswordResetHandler) ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("token", req.Token).
+		Required("password", req.Password).
+		MinLength("password", req.Password, 6)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	err := h.passwordResetUseCase.ResetPassword(&req)
+	if err != nil {
+		if err == domain.ErrInvalidResetToken {
+			response.BadRequest(w, err.Error())
+			return
+		}
+		response.InternalServerError(w, "Failed to reset password")
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Password has been reset"})
+}
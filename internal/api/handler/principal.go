@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"reservation-system/internal/api/middleware"
+	"reservation-system/internal/domain"
+	"reservation-system/pkg/response"
+)
+
+// currentPrincipal AuthMiddleware が設定した操作主体を取得（なければ 401 をレスポンス済み）
+func currentPrincipal(w http.ResponseWriter, r *http.Request) (*domain.Principal, bool) {
+	principal, ok := middleware.PrincipalFrom(r.Context())
+	if !ok {
+		response.Unauthorized(w, "Authentication required")
+		return nil, false
+	}
+	return principal, true
+}
+
+// actingUserID 操作主体と操作対象のユーザーIDを決定（許可されない場合はレスポンス済み）
+// requested が 0 なら本人、本人以外を指定できるのは代理操作を行う管理者のみ
+func actingUserID(w http.ResponseWriter, r *http.Request, requested uint) (*domain.Principal, uint, bool) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return nil, 0, false
+	}
+
+	userID, err := principal.ActAs(requested)
+	if err != nil {
+		response.Forbidden(w, "Only admins can act on behalf of other users")
+		return nil, 0, false
+	}
+	return principal, userID, true
+}
+
+// queryUserID クエリ文字列の user_id を取得（省略時は 0）
+func queryUserID(w http.ResponseWriter, r *http.Request) (uint, bool) {
+	userIDStr := r.URL.Query().Get("user_id")
+	if userIDStr == "" {
+		return 0, true
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid user ID")
+		return 0, false
+	}
+	return uint(userID), true
+}
@@ -2,6 +2,7 @@ package handler
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 	"strconv"
 	"time"
@@ -22,13 +23,69 @@ func NewReservationHandler() *ReservationHandler {
 	}
 }
 
+// slotFields 時間枠の指定
+// オフセット付きの start_at/end_at（RFC 3339）か、リソースのタイムゾーンでの date/start_time/end_time
+// （複数日にわたる場合は end_date も指定）
+type slotFields struct {
+	StartAt   string `json:"start_at"`
+	EndAt     string `json:"end_at"`
+	Date      string `json:"date"`
+	EndDate   string `json:"end_date"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+}
+
+// parseSlot 時間枠の指定を検証して変換（不正な場合はレスポンス済み）
+func parseSlot(w http.ResponseWriter, f *slotFields) (usecase.SlotRequest, bool) {
+	if f.StartAt != "" || f.EndAt != "" {
+		startAt, err := time.Parse(time.RFC3339, f.StartAt)
+		if err != nil {
+			response.BadRequest(w, "Invalid start_at format. Use RFC 3339 with an offset")
+			return usecase.SlotRequest{}, false
+		}
+		endAt, err := time.Parse(time.RFC3339, f.EndAt)
+		if err != nil {
+			response.BadRequest(w, "Invalid end_at format. Use RFC 3339 with an offset")
+			return usecase.SlotRequest{}, false
+		}
+		return usecase.SlotRequest{StartAt: startAt, EndAt: endAt}, true
+	}
+
+	v := validator.NewValidator()
+	v.Required("date", f.Date).
+		Required("start_time", f.StartTime).
+		Required("end_time", f.EndTime)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return usecase.SlotRequest{}, false
+	}
+
+	date, err := time.Parse("2006-01-02", f.Date)
+	if err != nil {
+		response.BadRequest(w, "Invalid date format. Use YYYY-MM-DD")
+		return usecase.SlotRequest{}, false
+	}
+
+	slot := usecase.SlotRequest{Date: date, StartTime: f.StartTime, EndTime: f.EndTime}
+	if f.EndDate != "" {
+		slot.EndDate, err = time.Parse("2006-01-02", f.EndDate)
+		if err != nil {
+			response.BadRequest(w, "Invalid end_date format. Use YYYY-MM-DD")
+			return usecase.SlotRequest{}, false
+		}
+	}
+
+	return slot, true
+}
+
 func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Request) {
 	var req struct {
-		UserID    uint   `json:"user_id"`
-		Date      string `json:"date"`
-		StartTime string `json:"start_time"`
-		EndTime   string `json:"end_time"`
-		Capacity  int    `json:"capacity"`
+		slotFields
+		UserID       uint `json:"user_id"`
+		ResourceID   uint `json:"resource_id"`
+		Quantity     *int `json:"quantity"`
+		JoinWaitlist bool `json:"join_waitlist"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -37,47 +94,61 @@ func (h *ReservationHandler) CreateReservation(w http.ResponseWriter, r *http.Re
 	}
 
 	v := validator.NewValidator()
-	v.Required("user_id", strconv.Itoa(int(req.UserID))).
-		Required("date", req.Date).
-		Required("start_time", req.StartTime).
-		Required("end_time", req.EndTime).
-		Required("capacity", strconv.Itoa(req.Capacity))
+	v.Required("resource_id", strconv.Itoa(int(req.ResourceID)))
 
 	if v.HasErrors() {
 		response.BadRequest(w, v.GetFirstError())
 		return
 	}
 
-	date, err := time.Parse("2006-01-02", req.Date)
-	if err != nil {
-		response.BadRequest(w, "Invalid date format. Use YYYY-MM-DD")
+	principal, userID, ok := actingUserID(w, r, req.UserID)
+	if !ok {
 		return
 	}
 
-	timeSlot, err := domain.NewTimeSlot(date, req.StartTime, req.EndTime, req.Capacity)
-	if err != nil {
-		response.BadRequest(w, err.Error())
+	slot, ok := parseSlot(w, &req.slotFields)
+	if !ok {
 		return
 	}
 
+	// 席数の指定がなければ1席
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
 	createReq := &usecase.CreateReservationRequest{
-		UserID:   req.UserID,
-		TimeSlot: timeSlot,
+		SlotRequest:  slot,
+		UserID:       userID,
+		ResourceID:   req.ResourceID,
+		Quantity:     quantity,
+		JoinWaitlist: req.JoinWaitlist,
 	}
 
-	resp, err := h.reservationUseCase.CreateReservation(createReq)
+	resp, err := h.reservationUseCase.CreateReservation(principal.OrganizationID, createReq)
 	if err != nil {
 		switch err {
 		case domain.ErrUserNotFound:
 			response.NotFound(w, "User not found")
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidTimeRange, domain.ErrSlotNotInSchedule, domain.ErrInvalidQuantity, domain.ErrBlackoutDate:
+			response.BadRequest(w, err.Error())
 		case domain.ErrCapacityExceeded:
 			response.BadRequest(w, "Capacity exceeded")
+		case domain.ErrEmailNotVerified:
+			response.Forbidden(w, err.Error())
 		default:
 			response.InternalServerError(w, "Failed to create reservation")
 		}
 		return
 	}
 
+	if resp.Waitlist != nil {
+		response.Accepted(w, resp)
+		return
+	}
+
 	response.Created(w, resp)
 }
 
@@ -94,13 +165,21 @@ func (h *ReservationHandler) GetReservation(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	reservation, err := h.reservationUseCase.GetReservation(uint(reservationID))
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationUseCase.GetReservation(uint(reservationID), principal)
 	if err != nil {
-		if err == domain.ErrReservationNotFound {
+		switch err {
+		case domain.ErrReservationNotFound:
 			response.NotFound(w, "Reservation not found")
-			return
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to view this reservation")
+		default:
+			response.InternalServerError(w, "Failed to get reservation")
 		}
-		response.InternalServerError(w, "Failed to get reservation")
 		return
 	}
 
@@ -108,20 +187,25 @@ func (h *ReservationHandler) GetReservation(w http.ResponseWriter, r *http.Reque
 }
 
 func (h *ReservationHandler) GetUserReservations(w http.ResponseWriter, r *http.Request) {
-	userIDStr := r.URL.Query().Get("user_id")
-	if userIDStr == "" {
-		response.BadRequest(w, "User ID is required")
+	userID, ok := queryUserID(w, r)
+	if !ok {
 		return
 	}
 
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		response.BadRequest(w, "Invalid user ID")
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
 		return
 	}
+	if userID == 0 {
+		userID = principal.UserID
+	}
 
-	reservations, err := h.reservationUseCase.GetUserReservations(uint(userID))
+	reservations, err := h.reservationUseCase.GetUserReservations(userID, principal)
 	if err != nil {
+		if err == domain.ErrUnauthorized {
+			response.Forbidden(w, "Not authorized to view this user's reservations")
+			return
+		}
 		response.InternalServerError(w, "Failed to get reservations")
 		return
 	}
@@ -129,6 +213,40 @@ func (h *ReservationHandler) GetUserReservations(w http.ResponseWriter, r *http.
 	response.Success(w, reservations)
 }
 
+func (h *ReservationHandler) GetResourceReservations(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("resource_id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.reservationUseCase.GetResourceReservations(uint(resourceID), principal)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not assigned to this resource")
+		default:
+			response.InternalServerError(w, "Failed to get reservations")
+		}
+		return
+	}
+
+	response.Success(w, reservations)
+}
+
 func (h *ReservationHandler) ConfirmReservation(w http.ResponseWriter, r *http.Request) {
 	var req usecase.ConfirmReservationRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -137,23 +255,32 @@ func (h *ReservationHandler) ConfirmReservation(w http.ResponseWriter, r *http.R
 	}
 
 	v := validator.NewValidator()
-	v.Required("reservation_id", strconv.Itoa(int(req.ReservationID))).
-		Required("user_id", strconv.Itoa(int(req.UserID)))
+	v.Required("reservation_id", strconv.Itoa(int(req.ReservationID)))
 
 	if v.HasErrors() {
 		response.BadRequest(w, v.GetFirstError())
 		return
 	}
 
-	err := h.reservationUseCase.ConfirmReservation(&req)
+	principal, userID, ok := actingUserID(w, r, req.UserID)
+	if !ok {
+		return
+	}
+	req.UserID = userID
+
+	err := h.reservationUseCase.ConfirmReservation(principal.OrganizationID, &req)
 	if err != nil {
 		switch err {
 		case domain.ErrReservationNotFound:
 			response.NotFound(w, "Reservation not found")
 		case domain.ErrUnauthorized:
 			response.Forbidden(w, "Not authorized to confirm this reservation")
+		case domain.ErrApprovalRequired:
+			response.Forbidden(w, err.Error())
 		case domain.ErrReservationNotPending:
 			response.BadRequest(w, "Reservation is not pending")
+		case domain.ErrReservationExpired:
+			response.BadRequest(w, "Reservation hold has expired")
 		default:
 			response.InternalServerError(w, "Failed to confirm reservation")
 		}
@@ -163,12 +290,165 @@ func (h *ReservationHandler) ConfirmReservation(w http.ResponseWriter, r *http.R
 	response.Success(w, map[string]string{"message": "Reservation confirmed"})
 }
 
+func (h *ReservationHandler) ApproveReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.ParseUint(PathParam(r, "id"), 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid reservation ID")
+		return
+	}
+
+	// 本文は省略可能（管理者が承認者の代理で操作する場合のみ user_id を指定）
+	var req struct {
+		UserID uint `json:"user_id"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && err != io.EOF {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, approverID, ok := actingUserID(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationUseCase.ApproveReservation(principal.OrganizationID, uint(reservationID), approverID)
+	if err != nil {
+		writeApprovalError(w, err, "Failed to approve reservation")
+		return
+	}
+
+	response.Success(w, reservation)
+}
+
+func (h *ReservationHandler) RejectReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.ParseUint(PathParam(r, "id"), 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid reservation ID")
+		return
+	}
+
+	var req usecase.RejectReservationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+	req.ReservationID = uint(reservationID)
+
+	v := validator.NewValidator()
+	v.Required("reason", req.Reason)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	principal, approverID, ok := actingUserID(w, r, req.ApproverID)
+	if !ok {
+		return
+	}
+	req.ApproverID = approverID
+
+	reservation, err := h.reservationUseCase.RejectReservation(principal.OrganizationID, &req)
+	if err != nil {
+		writeApprovalError(w, err, "Failed to reject reservation")
+		return
+	}
+
+	response.Success(w, reservation)
+}
+
+// writeApprovalError 承認・却下のエラーをレスポンスに変換
+func writeApprovalError(w http.ResponseWriter, err error, fallback string) {
+	switch err {
+	case domain.ErrReservationNotFound:
+		response.NotFound(w, "Reservation not found")
+	case domain.ErrResourceNotFound:
+		response.NotFound(w, "Resource not found")
+	case domain.ErrUnauthorized:
+		response.Forbidden(w, "Not an approver for this resource")
+	case domain.ErrReservationNotPending, domain.ErrNotAwaitingApproval, domain.ErrRejectionReasonRequired:
+		response.BadRequest(w, err.Error())
+	default:
+		response.InternalServerError(w, fallback)
+	}
+}
+
+func (h *ReservationHandler) GetApprovalQueue(w http.ResponseWriter, r *http.Request) {
+	requested, ok := queryUserID(w, r)
+	if !ok {
+		return
+	}
+
+	principal, approverID, ok := actingUserID(w, r, requested)
+	if !ok {
+		return
+	}
+
+	reservations, err := h.reservationUseCase.GetApprovalQueue(principal.OrganizationID, approverID)
+	if err != nil {
+		response.InternalServerError(w, "Failed to get approval queue")
+		return
+	}
+
+	response.Success(w, reservations)
+}
+
+func (h *ReservationHandler) RescheduleReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.ParseUint(PathParam(r, "id"), 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid reservation ID")
+		return
+	}
+
+	var req struct {
+		slotFields
+		UserID uint `json:"user_id"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, userID, ok := actingUserID(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	slot, ok := parseSlot(w, &req.slotFields)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationUseCase.RescheduleReservation(principal.OrganizationID, &usecase.RescheduleReservationRequest{
+		SlotRequest:   slot,
+		ReservationID: uint(reservationID),
+		UserID:        userID,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrReservationNotFound:
+			response.NotFound(w, "Reservation not found")
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to reschedule this reservation")
+		case domain.ErrCapacityExceeded, domain.ErrInvalidTimeRange, domain.ErrSlotNotInSchedule, domain.ErrReservationNotActive,
+			domain.ErrBlackoutDate, domain.ErrInvalidStatusTransition:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to reschedule reservation")
+		}
+		return
+	}
+
+	response.Success(w, reservation)
+}
+
 func (h *ReservationHandler) CancelReservation(w http.ResponseWriter, r *http.Request) {
 	reservationIDStr := r.URL.Query().Get("reservation_id")
-	userIDStr := r.URL.Query().Get("user_id")
-
-	if reservationIDStr == "" || userIDStr == "" {
-		response.BadRequest(w, "Reservation ID and User ID are required")
+	if reservationIDStr == "" {
+		response.BadRequest(w, "Reservation ID is required")
 		return
 	}
 
@@ -178,19 +458,27 @@ func (h *ReservationHandler) CancelReservation(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	userID, err := strconv.ParseUint(userIDStr, 10, 32)
-	if err != nil {
-		response.BadRequest(w, "Invalid user ID")
+	requested, ok := queryUserID(w, r)
+	if !ok {
+		return
+	}
+
+	principal, userID, ok := actingUserID(w, r, requested)
+	if !ok {
 		return
 	}
 
-	err = h.reservationUseCase.CancelReservation(uint(reservationID), uint(userID))
+	err = h.reservationUseCase.CancelReservation(principal.OrganizationID, uint(reservationID), userID)
 	if err != nil {
 		switch err {
 		case domain.ErrReservationNotFound:
 			response.NotFound(w, "Reservation not found")
 		case domain.ErrUnauthorized:
 			response.Forbidden(w, "Not authorized to cancel this reservation")
+		case domain.ErrCancellationNotAllowed:
+			response.Conflict(w, err.Error())
+		case domain.ErrReservationAlreadyCancelled, domain.ErrInvalidStatusTransition:
+			response.BadRequest(w, err.Error())
 		default:
 			response.InternalServerError(w, "Failed to cancel reservation")
 		}
@@ -199,3 +487,63 @@ func (h *ReservationHandler) CancelReservation(w http.ResponseWriter, r *http.Re
 
 	response.Success(w, map[string]string{"message": "Reservation cancelled"})
 }
+
+func (h *ReservationHandler) CheckInReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.ParseUint(PathParam(r, "id"), 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid reservation ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationUseCase.CheckInReservation(uint(reservationID), principal)
+	if err != nil {
+		switch err {
+		case domain.ErrReservationNotFound:
+			response.NotFound(w, "Reservation not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not assigned to this resource")
+		case domain.ErrInvalidStatusTransition, domain.ErrReservationEnded:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to check in reservation")
+		}
+		return
+	}
+
+	response.Success(w, reservation)
+}
+
+func (h *ReservationHandler) CompleteReservation(w http.ResponseWriter, r *http.Request) {
+	reservationID, err := strconv.ParseUint(PathParam(r, "id"), 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid reservation ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	reservation, err := h.reservationUseCase.CompleteReservation(uint(reservationID), principal)
+	if err != nil {
+		switch err {
+		case domain.ErrReservationNotFound:
+			response.NotFound(w, "Reservation not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not assigned to this resource")
+		case domain.ErrInvalidStatusTransition:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to complete reservation")
+		}
+		return
+	}
+
+	response.Success(w, reservation)
+}
@@ -0,0 +1,190 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type ReservationSeriesHandler struct {
+	seriesUseCase *usecase.ReservationSeriesUseCase
+}
+
+func NewReservationSeriesHandler() *ReservationSeriesHandler {
+	return &ReservationSeriesHandler{
+		seriesUseCase: usecase.NewReservationSeriesUseCase(),
+	}
+}
+
+func (h *ReservationSeriesHandler) CreateSeries(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		UserID     uint   `json:"user_id"`
+		ResourceID uint   `json:"resource_id"`
+		StartDate  string `json:"start_date"`
+		StartTime  string `json:"start_time"`
+		EndTime    string `json:"end_time"`
+		Quantity   *int   `json:"quantity"`
+		RRule      string `json:"rrule"`
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("resource_id", strconv.Itoa(int(req.ResourceID))).
+		Required("start_date", req.StartDate).
+		Required("start_time", req.StartTime).
+		Required("end_time", req.EndTime).
+		Required("rrule", req.RRule)
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	principal, userID, ok := actingUserID(w, r, req.UserID)
+	if !ok {
+		return
+	}
+
+	startDate, err := time.Parse("2006-01-02", req.StartDate)
+	if err != nil {
+		response.BadRequest(w, "Invalid start_date format. Use YYYY-MM-DD")
+		return
+	}
+
+	quantity := 1
+	if req.Quantity != nil {
+		quantity = *req.Quantity
+	}
+
+	resp, err := h.seriesUseCase.CreateSeries(principal.OrganizationID, &usecase.CreateSeriesRequest{
+		UserID:     userID,
+		ResourceID: req.ResourceID,
+		StartDate:  startDate,
+		StartTime:  req.StartTime,
+		EndTime:    req.EndTime,
+		Quantity:   quantity,
+		RRule:      req.RRule,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidRecurrenceRule, domain.ErrTooManyOccurrences, domain.ErrInvalidQuantity:
+			response.BadRequest(w, err.Error())
+		case domain.ErrEmailNotVerified:
+			response.Forbidden(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to create reservation series")
+		}
+		return
+	}
+
+	response.Created(w, resp)
+}
+
+func (h *ReservationSeriesHandler) GetSeries(w http.ResponseWriter, r *http.Request) {
+	seriesIDStr := r.URL.Query().Get("id")
+	if seriesIDStr == "" {
+		response.BadRequest(w, "Series ID is required")
+		return
+	}
+
+	seriesID, err := strconv.ParseUint(seriesIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid series ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.seriesUseCase.GetSeries(uint(seriesID), principal)
+	if err != nil {
+		switch err {
+		case domain.ErrSeriesNotFound:
+			response.NotFound(w, "Reservation series not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to view this reservation series")
+		default:
+			response.InternalServerError(w, "Failed to get reservation series")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *ReservationSeriesHandler) CancelSeries(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	seriesIDStr := query.Get("series_id")
+	scope := domain.SeriesCancelScope(query.Get("scope"))
+
+	if seriesIDStr == "" || scope == "" {
+		response.BadRequest(w, "Series ID and scope are required")
+		return
+	}
+
+	seriesID, err := strconv.ParseUint(seriesIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid series ID")
+		return
+	}
+
+	requested, ok := queryUserID(w, r)
+	if !ok {
+		return
+	}
+
+	principal, userID, ok := actingUserID(w, r, requested)
+	if !ok {
+		return
+	}
+
+	var reservationID uint64
+	if reservationIDStr := query.Get("reservation_id"); reservationIDStr != "" {
+		reservationID, err = strconv.ParseUint(reservationIDStr, 10, 32)
+		if err != nil {
+			response.BadRequest(w, "Invalid reservation ID")
+			return
+		}
+	}
+
+	cancelled, err := h.seriesUseCase.CancelSeries(principal.OrganizationID, &usecase.CancelSeriesRequest{
+		SeriesID:      uint(seriesID),
+		ReservationID: uint(reservationID),
+		UserID:        userID,
+		Scope:         scope,
+	})
+	if err != nil {
+		switch err {
+		case domain.ErrSeriesNotFound:
+			response.NotFound(w, "Reservation series not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to cancel this reservation series")
+		case domain.ErrCancellationNotAllowed:
+			response.Conflict(w, err.Error())
+		case domain.ErrInvalidCancelScope, domain.ErrReservationNotInSeries, domain.ErrReservationAlreadyCancelled,
+			domain.ErrInvalidStatusTransition:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to cancel reservation series")
+		}
+		return
+	}
+
+	response.Success(w, cancelled)
+}
@@ -0,0 +1,334 @@
+package handler
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+	"reservation-system/pkg/validator"
+)
+
+type ResourceHandler struct {
+	resourceUseCase *usecase.ResourceUseCase
+}
+
+func NewResourceHandler() *ResourceHandler {
+	return &ResourceHandler{
+		resourceUseCase: usecase.NewResourceUseCase(),
+	}
+}
+
+func (h *ResourceHandler) CreateResource(w http.ResponseWriter, r *http.Request) {
+	var req usecase.ResourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	v := validator.NewValidator()
+	v.Required("name", req.Name).
+		Required("type", string(req.Type)).
+		Required("capacity", strconv.Itoa(req.Capacity))
+
+	if v.HasErrors() {
+		response.BadRequest(w, v.GetFirstError())
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.CreateResource(principal.OrganizationID, &req)
+	if err != nil {
+		switch err {
+		case domain.ErrInvalidResource, domain.ErrInvalidResourceType, domain.ErrInvalidCapacity, domain.ErrInvalidBuffer, domain.ErrInvalidTimeZone:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to create resource")
+		}
+		return
+	}
+
+	response.Created(w, resource)
+}
+
+func (h *ResourceHandler) GetResource(w http.ResponseWriter, r *http.Request) {
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		h.listResources(w, principal.OrganizationID)
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	resource, err := h.resourceUseCase.GetResource(principal.OrganizationID, uint(resourceID))
+	if err != nil {
+		if err == domain.ErrResourceNotFound {
+			response.NotFound(w, "Resource not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to get resource")
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) listResources(w http.ResponseWriter, organizationID uint) {
+	resources, err := h.resourceUseCase.ListResources(organizationID)
+	if err != nil {
+		response.InternalServerError(w, "Failed to get resources")
+		return
+	}
+
+	response.Success(w, resources)
+}
+
+func (h *ResourceHandler) UpdateResource(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req usecase.ResourceRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.UpdateResource(principal.OrganizationID, uint(resourceID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidResource, domain.ErrInvalidResourceType, domain.ErrInvalidCapacity, domain.ErrInvalidBuffer, domain.ErrInvalidTimeZone:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update resource")
+		}
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) UpdateSchedule(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req usecase.UpdateScheduleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.UpdateSchedule(principal.OrganizationID, uint(resourceID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidSchedule:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update schedule")
+		}
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) UpdateCancellationPolicy(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req usecase.UpdateCancellationPolicyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.UpdateCancellationPolicy(principal.OrganizationID, uint(resourceID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrInvalidCancellationPolicy:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update cancellation policy")
+		}
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) UpdateApproval(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req usecase.UpdateApprovalRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.UpdateApproval(principal.OrganizationID, uint(resourceID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrUserNotFound:
+			response.BadRequest(w, "Approver not found")
+		case domain.ErrInvalidApprovers:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update approval settings")
+		}
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) UpdateStaff(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	var req usecase.UpdateStaffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resource, err := h.resourceUseCase.UpdateStaff(principal.OrganizationID, uint(resourceID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrResourceNotFound:
+			response.NotFound(w, "Resource not found")
+		case domain.ErrUserNotFound:
+			response.BadRequest(w, "Staff member not found")
+		case domain.ErrInvalidStaff:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update staff")
+		}
+		return
+	}
+
+	response.Success(w, resource)
+}
+
+func (h *ResourceHandler) DeleteResource(w http.ResponseWriter, r *http.Request) {
+	resourceIDStr := r.URL.Query().Get("id")
+	if resourceIDStr == "" {
+		response.BadRequest(w, "Resource ID is required")
+		return
+	}
+
+	resourceID, err := strconv.ParseUint(resourceIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid resource ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	err = h.resourceUseCase.DeleteResource(principal.OrganizationID, uint(resourceID))
+	if err != nil {
+		if err == domain.ErrResourceNotFound {
+			response.NotFound(w, "Resource not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to delete resource")
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Resource deleted"})
+}
@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"context"
 	"net/http"
 	"strings"
 )
@@ -12,6 +13,14 @@ type Route struct {
 	Middlewares []func(http.HandlerFunc) http.HandlerFunc
 }
 
+type pathParamsKey struct{}
+
+// PathParam ルートの ":name" セグメントに一致した値を取得
+func PathParam(r *http.Request, name string) string {
+	params, _ := r.Context().Value(pathParamsKey{}).(map[string]string)
+	return params[name]
+}
+
 type Router struct {
 	routes []Route
 }
@@ -56,6 +65,10 @@ func (r *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 				handler = middleware(handler)
 			}
 
+			if params := r.pathParams(route.Path, req.URL.Path); len(params) > 0 {
+				req = req.WithContext(context.WithValue(req.Context(), pathParamsKey{}, params))
+			}
+
 			handler.ServeHTTP(w, req)
 			return
 		}
@@ -91,3 +104,16 @@ func (r *Router) matchPath(routePath, requestPath string) bool {
 
 	return true
 }
+
+func (r *Router) pathParams(routePath, requestPath string) map[string]string {
+	routeParts := strings.Split(strings.Trim(routePath, "/"), "/")
+	requestParts := strings.Split(strings.Trim(requestPath, "/"), "/")
+
+	params := make(map[string]string)
+	for i := 0; i < len(routeParts) && i < len(requestParts); i++ {
+		if len(routeParts[i]) > 0 && routeParts[i][0] == ':' {
+			params[routeParts[i][1:]] = requestParts[i]
+		}
+	}
+	return params
+}
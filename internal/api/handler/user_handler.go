@@ -67,7 +67,12 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	user, err := h.userUseCase.GetUser(uint(userID))
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userUseCase.GetUser(principal.OrganizationID, uint(userID))
 	if err != nil {
 		if err == domain.ErrUserNotFound {
 			response.NotFound(w, "User not found")
@@ -80,6 +85,78 @@ func (h *UserHandler) GetUser(w http.ResponseWriter, r *http.Request) {
 	response.Success(w, user)
 }
 
+func (h *UserHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("id")
+	if userIDStr == "" {
+		response.BadRequest(w, "User ID is required")
+		return
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	var req usecase.UpdateRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequest(w, "Invalid request body")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userUseCase.UpdateRole(principal.OrganizationID, uint(userID), &req)
+	if err != nil {
+		switch err {
+		case domain.ErrUserNotFound:
+			response.NotFound(w, "User not found")
+		case domain.ErrInvalidRole:
+			response.BadRequest(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to update role")
+		}
+		return
+	}
+
+	response.Success(w, user)
+}
+
+// UnlockUser ログインの失敗でロックされたアカウントを解除する
+func (h *UserHandler) UnlockUser(w http.ResponseWriter, r *http.Request) {
+	userIDStr := r.URL.Query().Get("id")
+	if userIDStr == "" {
+		response.BadRequest(w, "User ID is required")
+		return
+	}
+
+	userID, err := strconv.ParseUint(userIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid user ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.userUseCase.UnlockUser(principal.OrganizationID, uint(userID))
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFound(w, "User not found")
+			return
+		}
+		response.InternalServerError(w, "Failed to unlock user")
+		return
+	}
+
+	response.Success(w, user)
+}
+
 func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req usecase.LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -97,13 +174,19 @@ func (h *UserHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	req.IPAddress = clientIP(r)
 	resp, err := h.userUseCase.Login(&req)
 	if err != nil {
-		if err == domain.ErrInvalidCredentials {
+		switch err {
+		case domain.ErrInvalidCredentials:
 			response.Unauthorized(w, "Invalid credentials")
-			return
+		case domain.ErrAccountLocked, domain.ErrTooManyLoginAttempts:
+			response.Error(w, http.StatusTooManyRequests, err.Error())
+		case domain.ErrNotOrganizationMember:
+			response.Forbidden(w, err.Error())
+		default:
+			response.InternalServerError(w, "Failed to login")
 		}
-		response.InternalServerError(w, "Failed to login")
 		return
 	}
 
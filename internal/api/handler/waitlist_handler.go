@@ -0,0 +1,95 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/usecase"
+	"reservation-system/pkg/response"
+)
+
+type WaitlistHandler struct {
+	waitlistUseCase *usecase.WaitlistUseCase
+}
+
+func NewWaitlistHandler() *WaitlistHandler {
+	return &WaitlistHandler{
+		waitlistUseCase: usecase.NewWaitlistUseCase(),
+	}
+}
+
+func (h *WaitlistHandler) GetEntry(w http.ResponseWriter, r *http.Request) {
+	entryIDStr := r.URL.Query().Get("id")
+	if entryIDStr == "" {
+		response.BadRequest(w, "Waitlist entry ID is required")
+		return
+	}
+
+	entryID, err := strconv.ParseUint(entryIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid waitlist entry ID")
+		return
+	}
+
+	principal, ok := currentPrincipal(w, r)
+	if !ok {
+		return
+	}
+
+	resp, err := h.waitlistUseCase.GetEntry(uint(entryID), principal)
+	if err != nil {
+		switch err {
+		case domain.ErrWaitlistEntryNotFound:
+			response.NotFound(w, "Waitlist entry not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to view this waitlist entry")
+		default:
+			response.InternalServerError(w, "Failed to get waitlist entry")
+		}
+		return
+	}
+
+	response.Success(w, resp)
+}
+
+func (h *WaitlistHandler) LeaveWaitlist(w http.ResponseWriter, r *http.Request) {
+	entryIDStr := r.URL.Query().Get("id")
+	if entryIDStr == "" {
+		response.BadRequest(w, "Waitlist entry ID is required")
+		return
+	}
+
+	entryID, err := strconv.ParseUint(entryIDStr, 10, 32)
+	if err != nil {
+		response.BadRequest(w, "Invalid waitlist entry ID")
+		return
+	}
+
+	requested, ok := queryUserID(w, r)
+	if !ok {
+		return
+	}
+
+	principal, userID, ok := actingUserID(w, r, requested)
+	if !ok {
+		return
+	}
+
+	err = h.waitlistUseCase.LeaveWaitlist(principal.OrganizationID, uint(entryID), userID)
+	if err != nil {
+		switch err {
+		case domain.ErrWaitlistEntryNotFound:
+			response.NotFound(w, "Waitlist entry not found")
+		case domain.ErrUnauthorized:
+			response.Forbidden(w, "Not authorized to leave this waitlist entry")
+		case domain.ErrWaitlistEntryNotWaiting:
+			response.BadRequest(w, "Waitlist entry is not waiting")
+		default:
+			response.InternalServerError(w, "Failed to leave waitlist")
+		}
+		return
+	}
+
+	response.Success(w, map[string]string{"message": "Left waitlist"})
+}
@@ -1,18 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 
+	"reservation-system/internal/domain"
 	"reservation-system/internal/infrastructure/jwt"
 	"reservation-system/pkg/response"
 )
 
-type AuthClaims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-}
-
 func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
@@ -28,14 +25,47 @@ func AuthMiddleware(next http.HandlerFunc) http.HandlerFunc {
 		}
 
 		claims, err := jwt.ValidateToken(tokenString)
+		if err == jwt.ErrTokenRevoked {
+			response.Unauthorized(w, "Token has been revoked")
+			return
+		}
 		if err != nil {
 			response.Unauthorized(w, "Invalid token")
 			return
 		}
 
-		r.Header.Set("X-User-ID", string(rune(claims.UserID)))
-		r.Header.Set("X-User-Email", claims.Email)
+		// 組織の導入前に発行されたトークンはテナントを特定できないため再ログインさせる
+		if claims.OrganizationID == 0 {
+			response.Unauthorized(w, "Token has no organization; log in again")
+			return
+		}
+
+		// 操作主体は型付きの値としてコンテキストに載せる（ハンドラーは PrincipalFrom で取得する）
+		principal := &domain.Principal{
+			UserID:         claims.UserID,
+			Email:          claims.Email,
+			Role:           roleFromClaims(claims.Role),
+			OrganizationID: claims.OrganizationID,
+			TokenID:        claims.ID,
+			TokenExpiresAt: claims.ExpiresAt.Time,
+		}
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	}
+}
+
+type principalKey struct{}
+
+// PrincipalFrom AuthMiddleware が設定した認証済みの操作主体を取得
+func PrincipalFrom(ctx context.Context) (*domain.Principal, bool) {
+	principal, ok := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal, ok
+}
 
-		next.ServeHTTP(w, r)
+// roleFromClaims 権限を含まない古いトークンや不明な権限は member として扱う
+func roleFromClaims(value string) domain.Role {
+	role, err := domain.ParseRole(value)
+	if err != nil {
+		return domain.RoleMember
 	}
+	return role
 }
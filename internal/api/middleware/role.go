@@ -0,0 +1,27 @@
+package middleware
+
+import (
+	"net/http"
+
+	"reservation-system/internal/domain"
+	"reservation-system/pkg/response"
+)
+
+// RequireRole いずれかの権限を持つ利用者だけに next の実行を許可する
+// AuthMiddleware の内側で使う
+func RequireRole(roles ...domain.Role) func(http.HandlerFunc) http.HandlerFunc {
+	return func(next http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			principal, ok := PrincipalFrom(r.Context())
+			if !ok {
+				response.Unauthorized(w, "Authentication required")
+				return
+			}
+			if !principal.HasRole(roles...) {
+				response.Forbidden(w, "Insufficient permissions")
+				return
+			}
+			next.ServeHTTP(w, r)
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package domain
+
+import (
+	"strings"
+	"time"
+)
+
+// ResourceApprover 承認が必要なリソースの予約を承認・却下できるユーザー
+type ResourceApprover struct {
+	ID         uint `json:"-" gorm:"primaryKey"`
+	ResourceID uint `json:"resource_id" gorm:"not null;uniqueIndex:idx_resource_approver"`
+	UserID     uint `json:"user_id" gorm:"not null;uniqueIndex:idx_resource_approver"`
+}
+
+// SetApprovalRequirement 予約に承認が必要かどうかと承認者を設定
+// 承認が必要な場合は承認者が1人以上必要（承認者がスタッフ以上の権限を持つかはユースケースで確認する）
+func (r *Resource) SetApprovalRequirement(required bool, approverIDs []uint) error {
+	approvers := make([]*ResourceApprover, 0, len(approverIDs))
+	seen := make(map[uint]bool, len(approverIDs))
+	for _, userID := range approverIDs {
+		if userID == 0 {
+			return ErrInvalidApprovers
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		approvers = append(approvers, &ResourceApprover{ResourceID: r.ID, UserID: userID})
+	}
+	if required && len(approvers) == 0 {
+		return ErrInvalidApprovers
+	}
+
+	r.RequiresApproval = required
+	r.Approvers = approvers
+	return nil
+}
+
+// CanApprove 指定ユーザーがリソースの承認者かチェック
+func (r *Resource) CanApprove(userID uint) bool {
+	for _, approver := range r.Approvers {
+		if approver.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+// RequireApproval 仮予約を承認待ちにする（承認が必要なリソースの予約作成時）
+// 承認待ちの仮予約は利用者自身では確定できず、保持期限による期限切れの対象にもならない
+func (r *Reservation) RequireApproval() {
+	if r.Status == StatusPending {
+		r.AwaitingApproval = true
+	}
+}
+
+// Approve 承認待ちの仮予約を承認して確定
+func (r *Reservation) Approve(approverID uint, now time.Time) error {
+	if r.Status != StatusPending {
+		return ErrReservationNotPending
+	}
+	if !r.AwaitingApproval {
+		return ErrNotAwaitingApproval
+	}
+
+	if err := r.transition(StatusConfirmed, now); err != nil {
+		return err
+	}
+	r.AwaitingApproval = false
+	r.ApprovedBy = &approverID
+	return nil
+}
+
+// Reject 承認待ちの仮予約を理由を付けて却下し、定員を解放する
+func (r *Reservation) Reject(approverID uint, reason string, now time.Time) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrRejectionReasonRequired
+	}
+	if r.Status != StatusPending {
+		return ErrReservationNotPending
+	}
+	if !r.AwaitingApproval {
+		return ErrNotAwaitingApproval
+	}
+
+	if err := r.transition(StatusRejected, now); err != nil {
+		return err
+	}
+	r.AwaitingApproval = false
+	r.RejectedBy = &approverID
+	r.RejectionReason = reason
+	return nil
+}
@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResourceSetApprovalRequirement(t *testing.T) {
+	resource, _ := NewResource("Board Room", ResourceTypeMeetingRoom, 10, "")
+	resource.ID = 1
+
+	if err := resource.SetApprovalRequirement(true, nil); err != ErrInvalidApprovers {
+		t.Errorf("Expected ErrInvalidApprovers without approvers, got %v", err)
+	}
+	if err := resource.SetApprovalRequirement(true, []uint{0}); err != ErrInvalidApprovers {
+		t.Errorf("Expected ErrInvalidApprovers for user 0, got %v", err)
+	}
+
+	if err := resource.SetApprovalRequirement(true, []uint{7, 8, 7}); err != nil {
+		t.Fatalf("SetApprovalRequirement() error = %v", err)
+	}
+	if !resource.RequiresApproval || len(resource.Approvers) != 2 {
+		t.Errorf("Expected approval with 2 approvers, got %v/%d", resource.RequiresApproval, len(resource.Approvers))
+	}
+	if !resource.CanApprove(7) || resource.CanApprove(9) {
+		t.Error("CanApprove() should only accept designated approvers")
+	}
+
+	if err := resource.SetApprovalRequirement(false, nil); err != nil {
+		t.Errorf("Disabling approval should not require approvers, got %v", err)
+	}
+}
+
+func TestReservationApprovalWorkflow(t *testing.T) {
+	now := time.Date(2026, 1, 10, 12, 0, 0, 0, time.UTC)
+	newAwaiting := func() *Reservation {
+		ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 10)
+		r, _ := NewReservation(1, 1, ts, 1)
+		r.CreatedAt = now.Add(-time.Hour)
+		r.RequireApproval()
+		return r
+	}
+
+	r := newAwaiting()
+	if err := r.Confirm(now); err != ErrApprovalRequired {
+		t.Errorf("Expected owner confirmation to require approval, got %v", err)
+	}
+	if r.IsHoldExpired(now, 15*time.Minute) {
+		t.Error("Reservations awaiting approval should not expire")
+	}
+
+	if err := r.Approve(2, now); err != nil {
+		t.Fatalf("Approve() error = %v", err)
+	}
+	if r.Status != StatusConfirmed || r.AwaitingApproval || r.ApprovedBy == nil || *r.ApprovedBy != 2 {
+		t.Errorf("Unexpected approved reservation %+v", r)
+	}
+	if err := r.Reject(2, "too late", now); err != ErrReservationNotPending {
+		t.Errorf("Expected ErrReservationNotPending, got %v", err)
+	}
+
+	r = newAwaiting()
+	if err := r.Reject(2, "  ", now); err != ErrRejectionReasonRequired {
+		t.Errorf("Expected ErrRejectionReasonRequired, got %v", err)
+	}
+	if err := r.Reject(2, "Room reserved for the board", now); err != nil {
+		t.Fatalf("Reject() error = %v", err)
+	}
+	if r.Status != StatusRejected || r.RejectionReason != "Room reserved for the board" || r.RejectedAt == nil {
+		t.Errorf("Unexpected rejected reservation %+v", r)
+	}
+	if r.Status.IsActive() {
+		t.Error("Rejected reservations should not consume capacity")
+	}
+
+	ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 10)
+	plain, _ := NewReservation(1, 1, ts, 1)
+	if err := plain.Approve(2, now); err != ErrNotAwaitingApproval {
+		t.Errorf("Expected ErrNotAwaitingApproval, got %v", err)
+	}
+}
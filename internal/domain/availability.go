@@ -0,0 +1,100 @@
+package domain
+
+import (
+	"time"
+)
+
+// MaxAvailabilityDays 空き状況を一度に検索できる最大日数
+const MaxAvailabilityDays = 31
+
+// SlotAvailability 時間枠ごとの空き状況（Booked は枠内で同時に使われる席数の最大値）
+// 日時はリソースのタイムゾーンのオフセット付きで返す
+type SlotAvailability struct {
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Capacity  int       `json:"capacity"`
+	Booked    int       `json:"booked"`
+	Remaining int       `json:"remaining"`
+	Available bool      `json:"available"`
+}
+
+// DayAvailability 日ごとの空き状況
+// 休業日は Closed とその理由を返し、時間枠は空にする
+type DayAvailability struct {
+	Date         string              `json:"date"`
+	Closed       bool                `json:"closed"`
+	ClosedReason string              `json:"closed_reason,omitempty"`
+	Slots        []*SlotAvailability `json:"slots"`
+}
+
+// ValidateDateRange 検索期間をチェック
+func ValidateDateRange(from, to time.Time) error {
+	if to.Before(from) {
+		return ErrInvalidDateRange
+	}
+	if to.Sub(from) >= MaxAvailabilityDays*24*time.Hour {
+		return ErrInvalidDateRange
+	}
+	return nil
+}
+
+// AvailabilityWindow リソースのタイムゾーンで from の0時から to の翌日0時までの時間帯
+func AvailabilityWindow(resource *Resource, from, to time.Time) *TimeSlot {
+	loc := resource.Location()
+	return &TimeSlot{
+		Date:      localDate(from),
+		EndDate:   localDate(to),
+		StartTime: "00:00",
+		EndTime:   "24:00",
+		StartAt:   time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc),
+		EndAt:     time.Date(to.Year(), to.Month(), to.Day()+1, 0, 0, 0, 0, loc),
+		TimeZone:  loc.String(),
+		Capacity:  resource.Capacity,
+	}
+}
+
+// ComputeAvailability 期間内の全時間枠について予約数と残り枠を集計
+// reservations は期間に重なるリソースの予約（有効でないものは無視する）
+// blackouts は期間内の休業日（他リソースのものは無視する）
+func ComputeAvailability(resource *Resource, from, to time.Time, reservations []*Reservation, blackouts Blackouts) ([]*DayAvailability, error) {
+	if err := ValidateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	days := make([]*DayAvailability, 0)
+	for date := from; !date.After(to); date = date.AddDate(0, 0, 1) {
+		day := &DayAvailability{
+			Date:  date.Format("2006-01-02"),
+			Slots: make([]*SlotAvailability, 0),
+		}
+
+		if blackout := blackouts.On(resource.ID, date); blackout != nil {
+			day.Closed = true
+			day.ClosedReason = blackout.Reason
+			days = append(days, day)
+			continue
+		}
+
+		for _, slot := range resource.SlotsOn(date) {
+			// 前後のバッファに掛かる予約や、日付をまたぐ予約も枠を埋める
+			booked := resource.PeakBooked(slot, reservations)
+
+			day.Slots = append(day.Slots, &SlotAvailability{
+				StartTime: slot.StartTime,
+				EndTime:   slot.EndTime,
+				StartAt:   slot.StartAt,
+				EndAt:     slot.EndAt,
+				Capacity:  slot.Capacity,
+				Booked:    booked,
+				Remaining: slot.Remaining(booked),
+				Available: slot.IsAvailable(booked, 1),
+			})
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
@@ -0,0 +1,166 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeAvailability(t *testing.T) {
+	resource, _ := NewResource("Room A", ResourceTypeMeetingRoom, 2, "")
+	resource.ID = 1
+	hours, _ := NewOpeningHours(time.Monday, "09:00", "11:00", 60)
+	_ = resource.SetSchedule([]*OpeningHours{hours})
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	tuesday := monday.AddDate(0, 0, 1)
+
+	newReservation := func(startTime, endTime string, status ReservationStatus) *Reservation {
+		ts, _ := NewTimeSlot(monday, startTime, endTime, resource.Capacity)
+		reservation, _ := NewReservation(1, resource.ID, ts, 1)
+		reservation.Status = status
+		return reservation
+	}
+
+	reservations := []*Reservation{
+		newReservation("09:00", "10:00", StatusConfirmed),
+		newReservation("09:00", "11:00", StatusPending),
+		newReservation("10:00", "11:00", StatusCancelled),
+	}
+
+	days, err := ComputeAvailability(resource, monday, tuesday, reservations, nil)
+	if err != nil {
+		t.Fatalf("ComputeAvailability() error = %v", err)
+	}
+
+	if len(days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(days))
+	}
+	if len(days[1].Slots) != 0 {
+		t.Errorf("Expected no slots on Tuesday, got %d", len(days[1].Slots))
+	}
+
+	slots := days[0].Slots
+	if len(slots) != 2 {
+		t.Fatalf("Expected 2 slots on Monday, got %d", len(slots))
+	}
+
+	if slots[0].Booked != 2 || slots[0].Remaining != 0 || slots[0].Available {
+		t.Errorf("09:00 slot = booked %d remaining %d available %v, want 2/0/false",
+			slots[0].Booked, slots[0].Remaining, slots[0].Available)
+	}
+	if slots[1].Booked != 1 || slots[1].Remaining != 1 || !slots[1].Available {
+		t.Errorf("10:00 slot = booked %d remaining %d available %v, want 1/1/true",
+			slots[1].Booked, slots[1].Remaining, slots[1].Available)
+	}
+}
+
+func TestValidateDateRange(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	if err := ValidateDateRange(from, from); err != nil {
+		t.Errorf("Single day range should be valid, got %v", err)
+	}
+	if err := ValidateDateRange(from, from.AddDate(0, 0, MaxAvailabilityDays-1)); err != nil {
+		t.Errorf("%d day range should be valid, got %v", MaxAvailabilityDays, err)
+	}
+	if err := ValidateDateRange(from, from.AddDate(0, 0, MaxAvailabilityDays)); err != ErrInvalidDateRange {
+		t.Errorf("Expected ErrInvalidDateRange for too long range, got %v", err)
+	}
+	if err := ValidateDateRange(from, from.AddDate(0, 0, -1)); err != ErrInvalidDateRange {
+		t.Errorf("Expected ErrInvalidDateRange for reversed range, got %v", err)
+	}
+}
+
+func TestComputeAvailabilitySumsQuantities(t *testing.T) {
+	resource, _ := NewResource("Table 7", ResourceTypeTable, 6, "")
+	resource.ID = 1
+	hours, _ := NewOpeningHours(time.Monday, "18:00", "20:00", 120)
+	_ = resource.SetSchedule([]*OpeningHours{hours})
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := NewTimeSlot(monday, "18:00", "20:00", resource.Capacity)
+	party, _ := NewReservation(1, resource.ID, ts, 4)
+
+	days, err := ComputeAvailability(resource, monday, monday, []*Reservation{party}, nil)
+	if err != nil {
+		t.Fatalf("ComputeAvailability() error = %v", err)
+	}
+
+	slot := days[0].Slots[0]
+	if slot.Booked != 4 || slot.Remaining != 2 || !slot.Available {
+		t.Errorf("slot = booked %d remaining %d available %v, want 4/2/true", slot.Booked, slot.Remaining, slot.Available)
+	}
+}
+
+func TestComputeAvailabilityAppliesBuffers(t *testing.T) {
+	resource, _ := NewResource("Room B", ResourceTypeMeetingRoom, 1, "")
+	resource.ID = 1
+	_ = resource.SetBuffers(15, 15)
+	hours, _ := NewOpeningHours(time.Monday, "09:00", "12:00", 60)
+	_ = resource.SetSchedule([]*OpeningHours{hours})
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := NewTimeSlot(monday, "10:00", "11:00", resource.Capacity)
+	booking, _ := NewReservation(1, resource.ID, ts, 1)
+
+	days, err := ComputeAvailability(resource, monday, monday, []*Reservation{booking}, nil)
+	if err != nil {
+		t.Fatalf("ComputeAvailability() error = %v", err)
+	}
+
+	// 10:00-11:00 の予約は準備・片付け時間を含めて 09:45-11:15 を塞ぐ
+	for i, slot := range days[0].Slots {
+		if slot.Available {
+			t.Errorf("slot %d (%s-%s) should be blocked by the buffered booking", i, slot.StartTime, slot.EndTime)
+		}
+	}
+}
+
+func TestComputeAvailabilityMarksBlackoutDays(t *testing.T) {
+	resource, _ := NewResource("Room C", ResourceTypeMeetingRoom, 1, "")
+	resource.ID = 1
+	monday, _ := NewOpeningHours(time.Monday, "09:00", "11:00", 60)
+	tuesday, _ := NewOpeningHours(time.Tuesday, "09:00", "11:00", 60)
+	_ = resource.SetSchedule([]*OpeningHours{monday, tuesday})
+
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	holiday, _ := NewBlackoutDate(nil, date, "Coming of Age Day")
+
+	days, err := ComputeAvailability(resource, date, date.AddDate(0, 0, 1), nil, Blackouts{holiday})
+	if err != nil {
+		t.Fatalf("ComputeAvailability() error = %v", err)
+	}
+
+	if !days[0].Closed || days[0].ClosedReason != "Coming of Age Day" || len(days[0].Slots) != 0 {
+		t.Errorf("Expected closed Monday with reason and no slots, got %+v", days[0])
+	}
+	if days[1].Closed || len(days[1].Slots) != 2 {
+		t.Errorf("Expected open Tuesday with 2 slots, got %+v", days[1])
+	}
+}
+
+func TestComputeAvailabilityCountsMultiDayReservations(t *testing.T) {
+	resource, _ := NewResource("Camera kit", ResourceTypeDevice, 1, "")
+	resource.ID = 1
+	var hours []*OpeningHours
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday} {
+		h, _ := NewOpeningHours(weekday, "09:00", "18:00", 540)
+		hours = append(hours, h)
+	}
+	_ = resource.SetSchedule(hours)
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := NewLocalTimeSpan(time.UTC, monday, "09:00", monday.AddDate(0, 0, 1), "18:00", resource.Capacity)
+	rental, _ := NewReservation(1, resource.ID, ts, 1)
+
+	days, err := ComputeAvailability(resource, monday, monday.AddDate(0, 0, 2), []*Reservation{rental}, nil)
+	if err != nil {
+		t.Fatalf("ComputeAvailability() error = %v", err)
+	}
+
+	for i, want := range []bool{false, false, true} {
+		if got := days[i].Slots[0].Available; got != want {
+			t.Errorf("%s available = %v, want %v", days[i].Date, got, want)
+		}
+	}
+}
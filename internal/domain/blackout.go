@@ -0,0 +1,178 @@
+package domain
+
+import (
+	"bufio"
+	"encoding/csv"
+	"io"
+	"strings"
+	"time"
+)
+
+// BlackoutDate 予約を受け付けない休業日エンティティ
+// ResourceID が nil の場合は全リソース共通の休業日
+type BlackoutDate struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	OrganizationID uint      `json:"organization_id" gorm:"index"`
+	ResourceID     *uint     `json:"resource_id,omitempty" gorm:"index"`
+	Date           time.Time `json:"date" gorm:"not null;index"`
+	Reason         string    `json:"reason" gorm:"not null"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// NewBlackoutDate 新規休業日を作成
+func NewBlackoutDate(resourceID *uint, date time.Time, reason string) (*BlackoutDate, error) {
+	b := &BlackoutDate{}
+	if err := b.Update(resourceID, date, reason); err != nil {
+		return nil, err
+	}
+	return b, nil
+}
+
+// Update 休業日の属性を更新
+func (b *BlackoutDate) Update(resourceID *uint, date time.Time, reason string) error {
+	if date.IsZero() {
+		return ErrInvalidBlackoutDate
+	}
+	if resourceID != nil && *resourceID == 0 {
+		return ErrInvalidResource
+	}
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrInvalidBlackoutDate
+	}
+
+	b.ResourceID = resourceID
+	b.Date = date
+	b.Reason = reason
+	return nil
+}
+
+// AppliesTo 指定リソースに適用される休業日かチェック
+func (b *BlackoutDate) AppliesTo(resourceID uint) bool {
+	return b.ResourceID == nil || *b.ResourceID == resourceID
+}
+
+// Blackouts 休業日の一覧
+type Blackouts []*BlackoutDate
+
+// On 指定リソース・日付に適用される休業日を返す（なければ nil）
+func (bs Blackouts) On(resourceID uint, date time.Time) *BlackoutDate {
+	for _, b := range bs {
+		if b.AppliesTo(resourceID) && sameDate(b.Date, date) {
+			return b
+		}
+	}
+	return nil
+}
+
+// During 時間枠がかかるいずれかの日付に適用される休業日を返す（なければ nil）
+func (bs Blackouts) During(resourceID uint, ts *TimeSlot) *BlackoutDate {
+	for _, date := range ts.Dates() {
+		if b := bs.On(resourceID, date); b != nil {
+			return b
+		}
+	}
+	return nil
+}
+
+// ParseHolidaysCSV "YYYY-MM-DD,理由" 形式のCSVから休業日を読み込む
+// 1行目が見出し行（date,reason）の場合は読み飛ばす
+func ParseHolidaysCSV(r io.Reader, resourceID *uint) ([]*BlackoutDate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, ErrInvalidHolidayFile
+	}
+
+	var blackouts []*BlackoutDate
+	for i, record := range records {
+		if len(record) == 0 || (len(record) == 1 && strings.TrimSpace(record[0]) == "") {
+			continue
+		}
+		if i == 0 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+		if len(record) < 2 {
+			return nil, ErrInvalidHolidayFile
+		}
+
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(record[0]))
+		if err != nil {
+			return nil, ErrInvalidHolidayFile
+		}
+		blackout, err := NewBlackoutDate(resourceID, date, record[1])
+		if err != nil {
+			return nil, ErrInvalidHolidayFile
+		}
+		blackouts = append(blackouts, blackout)
+	}
+	return blackouts, nil
+}
+
+// ParseHolidaysICS iCalendar の終日イベント（DTSTART;VALUE=DATE）から休業日を読み込む
+// 複数日にわたるイベントは DTEND の前日まで展開する
+func ParseHolidaysICS(r io.Reader, resourceID *uint) ([]*BlackoutDate, error) {
+	var (
+		blackouts  []*BlackoutDate
+		inEvent    bool
+		start, end time.Time
+		summary    string
+	)
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// プロパティ名のパラメータ（;VALUE=DATE など）は無視する
+		name, _, _ = strings.Cut(name, ";")
+
+		switch strings.ToUpper(name) {
+		case "BEGIN":
+			if strings.EqualFold(value, "VEVENT") {
+				inEvent = true
+				start, end, summary = time.Time{}, time.Time{}, ""
+			}
+		case "DTSTART":
+			if inEvent {
+				start, _ = time.Parse("20060102", value)
+			}
+		case "DTEND":
+			if inEvent {
+				end, _ = time.Parse("20060102", value)
+			}
+		case "SUMMARY":
+			if inEvent {
+				summary = value
+			}
+		case "END":
+			if !inEvent || !strings.EqualFold(value, "VEVENT") {
+				continue
+			}
+			inEvent = false
+			if start.IsZero() {
+				return nil, ErrInvalidHolidayFile
+			}
+			if end.IsZero() || !end.After(start) {
+				end = start.AddDate(0, 0, 1)
+			}
+			for date := start; date.Before(end); date = date.AddDate(0, 0, 1) {
+				blackout, err := NewBlackoutDate(resourceID, date, summary)
+				if err != nil {
+					return nil, ErrInvalidHolidayFile
+				}
+				blackouts = append(blackouts, blackout)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, ErrInvalidHolidayFile
+	}
+	return blackouts, nil
+}
@@ -0,0 +1,121 @@
+package domain
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewBlackoutDate(t *testing.T) {
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	zero := uint(0)
+
+	tests := []struct {
+		name       string
+		resourceID *uint
+		date       time.Time
+		reason     string
+		wantErr    error
+	}{
+		{name: "Global holiday", resourceID: nil, date: date, reason: "New Year's Day", wantErr: nil},
+		{name: "Missing reason", resourceID: nil, date: date, reason: "  ", wantErr: ErrInvalidBlackoutDate},
+		{name: "Zero date", resourceID: nil, date: time.Time{}, reason: "Closed", wantErr: ErrInvalidBlackoutDate},
+		{name: "Invalid resource", resourceID: &zero, date: date, reason: "Closed", wantErr: ErrInvalidResource},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewBlackoutDate(tt.resourceID, tt.date, tt.reason)
+			if err != tt.wantErr {
+				t.Errorf("NewBlackoutDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBlackoutsOn(t *testing.T) {
+	roomA, roomB := uint(1), uint(2)
+	newYear := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	maintenance := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	global, _ := NewBlackoutDate(nil, newYear, "New Year's Day")
+	roomOnly, _ := NewBlackoutDate(&roomA, maintenance, "Maintenance")
+	blackouts := Blackouts{global, roomOnly}
+
+	if b := blackouts.On(roomB, newYear); b != global {
+		t.Errorf("Expected global blackout to apply to every resource, got %+v", b)
+	}
+	if b := blackouts.On(roomA, maintenance); b != roomOnly {
+		t.Errorf("Expected resource blackout, got %+v", b)
+	}
+	if b := blackouts.On(roomB, maintenance); b != nil {
+		t.Errorf("Expected no blackout for another resource, got %+v", b)
+	}
+}
+
+func TestBlackoutsDuring(t *testing.T) {
+	room := uint(1)
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	holiday, _ := NewBlackoutDate(nil, monday.AddDate(0, 0, 1), "Holiday")
+	blackouts := Blackouts{holiday}
+
+	span, _ := NewLocalTimeSpan(time.UTC, monday, "09:00", monday.AddDate(0, 0, 2), "18:00", 1)
+	if b := blackouts.During(room, span); b != holiday {
+		t.Errorf("Expected blackout in the middle of the span, got %+v", b)
+	}
+
+	single, _ := NewTimeSlot(monday, "09:00", "18:00", 1)
+	if b := blackouts.During(room, single); b != nil {
+		t.Errorf("Expected no blackout, got %+v", b)
+	}
+}
+
+func TestParseHolidaysCSV(t *testing.T) {
+	input := "date,reason\n2026-01-01,New Year's Day\n\n2026-01-12, Coming of Age Day\n"
+	blackouts, err := ParseHolidaysCSV(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("ParseHolidaysCSV() error = %v", err)
+	}
+	if len(blackouts) != 2 {
+		t.Fatalf("Expected 2 holidays, got %d", len(blackouts))
+	}
+	if blackouts[1].Reason != "Coming of Age Day" || blackouts[1].Date.Day() != 12 {
+		t.Errorf("Unexpected second holiday %+v", blackouts[1])
+	}
+
+	if _, err := ParseHolidaysCSV(strings.NewReader("01/01/2026,New Year\n"), nil); err != ErrInvalidHolidayFile {
+		t.Errorf("Expected ErrInvalidHolidayFile, got %v", err)
+	}
+}
+
+func TestParseHolidaysICS(t *testing.T) {
+	input := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20260101",
+		"DTEND;VALUE=DATE:20260102",
+		"SUMMARY:New Year's Day",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"DTSTART;VALUE=DATE:20260429",
+		"DTEND;VALUE=DATE:20260501",
+		"SUMMARY:Spring Break",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	resourceID := uint(3)
+	blackouts, err := ParseHolidaysICS(strings.NewReader(input), &resourceID)
+	if err != nil {
+		t.Fatalf("ParseHolidaysICS() error = %v", err)
+	}
+	if len(blackouts) != 3 {
+		t.Fatalf("Expected 3 blackout days (multi-day event expanded), got %d", len(blackouts))
+	}
+	if got := blackouts[2].Date.Format("2006-01-02"); got != "2026-04-30" || blackouts[2].Reason != "Spring Break" {
+		t.Errorf("Unexpected last blackout %s %q", got, blackouts[2].Reason)
+	}
+	if *blackouts[0].ResourceID != resourceID {
+		t.Errorf("Expected imported blackouts to belong to resource %d", resourceID)
+	}
+}
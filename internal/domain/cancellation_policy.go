@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// CancellationTier キャンセル料の段階エンティティ
+// 開始の MinutesBefore 分前までのキャンセルに FeePercent のキャンセル料がかかる
+type CancellationTier struct {
+	ID            uint `json:"id" gorm:"primaryKey"`
+	ResourceID    uint `json:"resource_id" gorm:"not null;index"`
+	MinutesBefore int  `json:"minutes_before" gorm:"not null"`
+	FeePercent    int  `json:"fee_percent" gorm:"not null"`
+}
+
+// NewCancellationTier 新規キャンセル料段階を作成
+func NewCancellationTier(minutesBefore, feePercent int) (*CancellationTier, error) {
+	if minutesBefore < 0 {
+		return nil, ErrInvalidCancellationPolicy
+	}
+	if feePercent < 0 || feePercent > 100 {
+		return nil, ErrInvalidCancellationPolicy
+	}
+
+	return &CancellationTier{
+		MinutesBefore: minutesBefore,
+		FeePercent:    feePercent,
+	}, nil
+}
+
+// CancellationPolicy リソースのキャンセルポリシー
+// 段階が1つもない場合は開始まで無料でキャンセルできる
+type CancellationPolicy []*CancellationTier
+
+// NewCancellationPolicy キャンセルポリシーを作成
+// 同じ MinutesBefore の段階が重複する場合はエラー
+func NewCancellationPolicy(tiers []*CancellationTier) (CancellationPolicy, error) {
+	policy := CancellationPolicy(tiers).sorted()
+	for i := 1; i < len(policy); i++ {
+		if policy[i-1].MinutesBefore == policy[i].MinutesBefore {
+			return nil, ErrInvalidCancellationPolicy
+		}
+	}
+	return policy, nil
+}
+
+// CancellationFee キャンセル時点で適用されるキャンセル料
+type CancellationFee struct {
+	Percent int
+	Reason  string
+}
+
+// Evaluate 開始日時に対して now 時点でのキャンセル料を判定
+// 開始後、またはどの段階にも該当しない場合は ErrCancellationNotAllowed
+func (p CancellationPolicy) Evaluate(startAt, now time.Time) (*CancellationFee, error) {
+	if !now.Before(startAt) {
+		return nil, ErrCancellationNotAllowed
+	}
+	if len(p) == 0 {
+		return &CancellationFee{Reason: "no cancellation policy"}, nil
+	}
+
+	lead := startAt.Sub(now)
+	for _, tier := range p.sorted() {
+		if lead >= time.Duration(tier.MinutesBefore)*time.Minute {
+			return &CancellationFee{
+				Percent: tier.FeePercent,
+				Reason:  fmt.Sprintf("cancelled at least %d minutes before start", tier.MinutesBefore),
+			}, nil
+		}
+	}
+	return nil, ErrCancellationNotAllowed
+}
+
+// sorted DBから読み込んだ順序に依存しないよう MinutesBefore の降順に並べる
+func (p CancellationPolicy) sorted() CancellationPolicy {
+	sorted := make(CancellationPolicy, len(p))
+	copy(sorted, p)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].MinutesBefore > sorted[j].MinutesBefore
+	})
+	return sorted
+}
@@ -0,0 +1,95 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewCancellationTier(t *testing.T) {
+	tests := []struct {
+		name          string
+		minutesBefore int
+		feePercent    int
+		wantErr       bool
+	}{
+		{name: "Free cancellation", minutesBefore: 1440, feePercent: 0, wantErr: false},
+		{name: "Full fee until start", minutesBefore: 0, feePercent: 100, wantErr: false},
+		{name: "Negative minutes", minutesBefore: -1, feePercent: 0, wantErr: true},
+		{name: "Fee above 100%", minutesBefore: 60, feePercent: 101, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewCancellationTier(tt.minutesBefore, tt.feePercent)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCancellationTier() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewCancellationPolicyRejectsDuplicateTiers(t *testing.T) {
+	a, _ := NewCancellationTier(60, 0)
+	b, _ := NewCancellationTier(60, 50)
+	if _, err := NewCancellationPolicy([]*CancellationTier{a, b}); err != ErrInvalidCancellationPolicy {
+		t.Errorf("Expected ErrInvalidCancellationPolicy, got %v", err)
+	}
+}
+
+func TestCancellationPolicyEvaluate(t *testing.T) {
+	free, _ := NewCancellationTier(24*60, 0)
+	half, _ := NewCancellationTier(2*60, 50)
+	// DBからの読み込み順に依存しないことを確認するため、昇順で渡す
+	policy := CancellationPolicy{half, free}
+	start := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		now     time.Time
+		want    int
+		wantErr error
+	}{
+		{name: "Two days before", now: start.Add(-48 * time.Hour), want: 0},
+		{name: "Exactly 24h before", now: start.Add(-24 * time.Hour), want: 0},
+		{name: "Three hours before", now: start.Add(-3 * time.Hour), want: 50},
+		{name: "One hour before", now: start.Add(-time.Hour), wantErr: ErrCancellationNotAllowed},
+		{name: "After start", now: start.Add(time.Minute), wantErr: ErrCancellationNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fee, err := policy.Evaluate(start, tt.now)
+			if err != tt.wantErr {
+				t.Fatalf("Evaluate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && fee.Percent != tt.want {
+				t.Errorf("Evaluate() fee = %d%%, want %d%%", fee.Percent, tt.want)
+			}
+		})
+	}
+
+	if _, err := CancellationPolicy(nil).Evaluate(start, start.Add(-time.Minute)); err != nil {
+		t.Errorf("Expected free cancellation without a policy, got %v", err)
+	}
+}
+
+func TestReservationCancelRecordsFee(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 10)
+	half, _ := NewCancellationTier(2*60, 50)
+	policy, _ := NewCancellationPolicy([]*CancellationTier{half})
+
+	reservation, _ := NewReservation(1, 1, ts, 1)
+	if err := reservation.Cancel(ts.StartAt.Add(-time.Hour), policy); err != ErrCancellationNotAllowed {
+		t.Errorf("Expected ErrCancellationNotAllowed, got %v", err)
+	}
+	if reservation.Status != StatusPending {
+		t.Errorf("Expected rejected cancellation to keep status pending, got %s", reservation.Status)
+	}
+
+	if err := reservation.Cancel(ts.StartAt.Add(-3*time.Hour), policy); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if reservation.CancellationFeePercent != 50 || reservation.CancellationReason == "" {
+		t.Errorf("Expected 50%% fee with a reason, got %d%% %q", reservation.CancellationFeePercent, reservation.CancellationReason)
+	}
+}
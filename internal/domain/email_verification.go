@@ -0,0 +1,68 @@
+package domain
+
+import "time"
+
+// EmailVerificationToken メールアドレス確認トークン
+// 平文は確認メールでのみ送り、サーバーには SHA-256 ハッシュを保存する
+// 1回使うか有効期限を過ぎると使えなくなり、確認が済むと未使用のトークンもすべて使えなくなる
+type EmailVerificationToken struct {
+	ID        uint       `json:"-" gorm:"primaryKey"`
+	UserID    uint       `json:"-" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"-" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"-"`
+}
+
+// NewEmailVerificationToken メールアドレス確認トークンを作成し、保存用のエンティティと平文を返す
+func NewEmailVerificationToken(userID uint, now time.Time, ttl time.Duration) (*EmailVerificationToken, string, error) {
+	if userID == 0 || ttl <= 0 {
+		return nil, "", ErrInvalidVerificationToken
+	}
+
+	plaintext, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &EmailVerificationToken{
+		UserID:    userID,
+		TokenHash: HashToken(plaintext),
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}, plaintext, nil
+}
+
+// Use メールアドレス確認トークンを使用済みにする
+func (t *EmailVerificationToken) Use(now time.Time) error {
+	if t.UsedAt != nil || !now.Before(t.ExpiresAt) {
+		return ErrInvalidVerificationToken
+	}
+
+	t.UsedAt = &now
+	return nil
+}
+
+// ResendThrottle 確認メールの再送制限
+// 直前の送信から Interval が経過するまで、また24時間以内の送信が DailyLimit 通に達している間は再送できない
+type ResendThrottle struct {
+	Interval   time.Duration
+	DailyLimit int
+}
+
+// Allow 過去の送信日時 sentAt をもとに、now の時点で再送できるかチェック
+func (t ResendThrottle) Allow(sentAt []time.Time, now time.Time) error {
+	sentToday := 0
+	for _, at := range sentAt {
+		if now.Sub(at) < t.Interval {
+			return ErrVerificationThrottled
+		}
+		if now.Sub(at) < 24*time.Hour {
+			sentToday++
+		}
+	}
+	if t.DailyLimit > 0 && sentToday >= t.DailyLimit {
+		return ErrVerificationThrottled
+	}
+	return nil
+}
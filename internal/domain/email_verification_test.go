@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEmailVerificationTokenUse(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, plaintext, err := NewEmailVerificationToken(1, now, 24*time.Hour)
+	if err != nil {
+		t.Fatalf("NewEmailVerificationToken() error = %v", err)
+	}
+	if token.TokenHash != HashToken(plaintext) || token.TokenHash == plaintext {
+		t.Error("Expected only the hash of the plaintext token to be stored")
+	}
+
+	if err := token.Use(now.Add(time.Hour)); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := token.Use(now.Add(time.Hour)); err != ErrInvalidVerificationToken {
+		t.Errorf("Expected ErrInvalidVerificationToken on second use, got %v", err)
+	}
+
+	expired, _, _ := NewEmailVerificationToken(1, now, 24*time.Hour)
+	if err := expired.Use(now.Add(24 * time.Hour)); err != ErrInvalidVerificationToken {
+		t.Errorf("Expected ErrInvalidVerificationToken after expiry, got %v", err)
+	}
+}
+
+func TestUserVerifyEmail(t *testing.T) {
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+	if user.IsEmailVerified() {
+		t.Fatal("Expected new users to start unverified")
+	}
+
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	if err := user.VerifyEmail(now); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if !user.IsEmailVerified() || !user.EmailVerifiedAt.Equal(now) {
+		t.Errorf("Expected email to be verified at %v, got %v", now, user.EmailVerifiedAt)
+	}
+	if err := user.VerifyEmail(now.Add(time.Hour)); err != ErrEmailAlreadyVerified {
+		t.Errorf("Expected ErrEmailAlreadyVerified, got %v", err)
+	}
+}
+
+func TestResendThrottleAllow(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	throttle := ResendThrottle{Interval: time.Minute, DailyLimit: 3}
+
+	tests := []struct {
+		name    string
+		sentAt  []time.Time
+		wantErr error
+	}{
+		{"never sent", nil, nil},
+		{"sent after the interval", []time.Time{now.Add(-2 * time.Minute)}, nil},
+		{"sent within the interval", []time.Time{now.Add(-30 * time.Second)}, ErrVerificationThrottled},
+		{"daily limit reached", []time.Time{now.Add(-time.Hour), now.Add(-2 * time.Hour), now.Add(-3 * time.Hour)}, ErrVerificationThrottled},
+		{"older sends do not count", []time.Time{now.Add(-time.Hour), now.Add(-2 * time.Hour), now.Add(-25 * time.Hour)}, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := throttle.Allow(tt.sentAt, now); err != tt.wantErr {
+				t.Errorf("Allow() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -15,4 +15,57 @@ var (
 	ErrReservationNotPending       = errors.New("reservation is not pending")
 	ErrReservationAlreadyCancelled = errors.New("reservation is already cancelled")
 	ErrCapacityExceeded            = errors.New("capacity exceeded")
+	ErrResourceNotFound            = errors.New("resource not found")
+	ErrInvalidResource             = errors.New("invalid resource")
+	ErrInvalidResourceType         = errors.New("invalid resource type")
+	ErrInvalidSchedule             = errors.New("invalid schedule")
+	ErrSlotNotInSchedule           = errors.New("time slot does not match the published schedule")
+	ErrInvalidDateRange            = errors.New("invalid date range")
+	ErrInvalidQuantity             = errors.New("quantity must be positive")
+	ErrInvalidRecurrenceRule       = errors.New("invalid recurrence rule")
+	ErrTooManyOccurrences          = errors.New("recurrence rule produces too many occurrences")
+	ErrSeriesNotFound              = errors.New("reservation series not found")
+	ErrInvalidCancelScope          = errors.New("invalid cancel scope")
+	ErrReservationNotInSeries      = errors.New("reservation does not belong to the series")
+	ErrWaitlistEntryNotFound       = errors.New("waitlist entry not found")
+	ErrWaitlistEntryNotWaiting     = errors.New("waitlist entry is not waiting")
+	ErrHoldNotExpired              = errors.New("reservation hold has not expired")
+	ErrReservationExpired          = errors.New("reservation hold has expired")
+	ErrReservationNotActive        = errors.New("reservation is not active")
+	ErrInvalidStatusTransition     = errors.New("invalid reservation status transition")
+	ErrCancellationNotAllowed      = errors.New("cancellation is not allowed by the cancellation policy")
+	ErrInvalidCancellationPolicy   = errors.New("invalid cancellation policy")
+	ErrInvalidBuffer               = errors.New("buffer minutes must be between 0 and 1440")
+	ErrBlackoutDate                = errors.New("time slot falls on a blackout date")
+	ErrBlackoutNotFound            = errors.New("blackout date not found")
+	ErrInvalidBlackoutDate         = errors.New("invalid blackout date")
+	ErrInvalidHolidayFile          = errors.New("invalid holiday file")
+	ErrInvalidTimeZone             = errors.New("invalid time zone")
+	ErrReservationEnded            = errors.New("reservation has already ended")
+	ErrNoShowGraceNotElapsed       = errors.New("no-show grace period has not elapsed")
+	ErrApprovalRequired            = errors.New("reservation must be approved by an approver")
+	ErrNotAwaitingApproval         = errors.New("reservation is not awaiting approval")
+	ErrRejectionReasonRequired     = errors.New("rejection reason is required")
+	ErrInvalidApprovers            = errors.New("invalid approvers")
+	ErrInvalidRole                 = errors.New("invalid role")
+	ErrInvalidStaff                = errors.New("invalid staff")
+	ErrOrganizationNotFound        = errors.New("organization not found")
+	ErrInvalidOrganization         = errors.New("invalid organization")
+	ErrOrganizationExists          = errors.New("organization slug already exists")
+	ErrInvalidMembership           = errors.New("invalid membership")
+	ErrNotOrganizationMember       = errors.New("user is not a member of the organization")
+	ErrInvalidRefreshToken         = errors.New("invalid refresh token")
+	ErrRefreshTokenReused          = errors.New("refresh token has already been used")
+	ErrInvalidResetToken           = errors.New("invalid or expired password reset token")
+	ErrInvalidVerificationToken    = errors.New("invalid or expired email verification token")
+	ErrEmailNotVerified            = errors.New("email address has not been verified")
+	ErrEmailAlreadyVerified        = errors.New("email address is already verified")
+	ErrVerificationThrottled       = errors.New("verification email was sent too recently")
+	ErrInvalidMFAChallenge         = errors.New("invalid or expired MFA challenge")
+	ErrInvalidMFACode              = errors.New("invalid authentication code")
+	ErrTOTPAlreadyEnabled          = errors.New("two-factor authentication is already enabled")
+	ErrTOTPNotEnrolled             = errors.New("two-factor authentication enrollment has not been started")
+	ErrTOTPNotEnabled              = errors.New("two-factor authentication is not enabled")
+	ErrAccountLocked               = errors.New("account is temporarily locked due to failed login attempts")
+	ErrTooManyLoginAttempts        = errors.New("too many failed login attempts from this address")
 )
@@ -0,0 +1,64 @@
+package domain
+
+import "time"
+
+// LockoutPolicy ログイン失敗時の待ち時間とロックアウトの設定
+// FreeAttempts 回までの失敗は待ち時間なしで、以降は失敗するたびに BaseDelay から倍々に待ち時間を延ばし、
+// MaxAttempts 回に達すると LockoutDuration のあいだログインを受け付けない
+type LockoutPolicy struct {
+	FreeAttempts    int
+	BaseDelay       time.Duration
+	MaxAttempts     int
+	LockoutDuration time.Duration
+}
+
+// LockFor failures 回目の失敗の後にログインを受け付けない時間
+func (p LockoutPolicy) LockFor(failures int) time.Duration {
+	if failures <= p.FreeAttempts {
+		return 0
+	}
+	if p.MaxAttempts > 0 && failures >= p.MaxAttempts {
+		return p.LockoutDuration
+	}
+
+	delay := p.BaseDelay
+	for i := p.FreeAttempts + 1; i < failures && delay < p.LockoutDuration; i++ {
+		delay *= 2
+	}
+	if delay > p.LockoutDuration {
+		return p.LockoutDuration
+	}
+	return delay
+}
+
+// LockedUntil 最後の失敗が lastFailure で通算 failures 回失敗した場合に、次のログインを受け付ける日時
+func (p LockoutPolicy) LockedUntil(failures int, lastFailure time.Time) time.Time {
+	return lastFailure.Add(p.LockFor(failures))
+}
+
+// LoginFailure IP アドレスごとのログイン失敗の記録
+type LoginFailure struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	IPAddress string    `json:"-" gorm:"not null;index:idx_login_failures_ip_created"`
+	CreatedAt time.Time `json:"-" gorm:"not null;index:idx_login_failures_ip_created"`
+}
+
+// IsLocked ログインを一時的に受け付けない状態かチェック
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && now.Before(*u.LockedUntil)
+}
+
+// RecordFailedLogin 通算 failures 回目のログイン失敗を記録し、ポリシーに従ってロックする
+func (u *User) RecordFailedLogin(failures int, policy LockoutPolicy, now time.Time) {
+	u.FailedAttempts = failures
+	if delay := policy.LockFor(failures); delay > 0 {
+		lockedUntil := now.Add(delay)
+		u.LockedUntil = &lockedUntil
+	}
+}
+
+// ClearFailedLogins 失敗回数をリセットしてロックを解除する
+func (u *User) ClearFailedLogins() {
+	u.FailedAttempts = 0
+	u.LockedUntil = nil
+}
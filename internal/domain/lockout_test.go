@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLockoutPolicyLockFor(t *testing.T) {
+	policy := LockoutPolicy{
+		FreeAttempts:    3,
+		BaseDelay:       time.Second,
+		MaxAttempts:     10,
+		LockoutDuration: 15 * time.Minute,
+	}
+
+	tests := []struct {
+		failures int
+		want     time.Duration
+	}{
+		{0, 0},
+		{3, 0},
+		{4, time.Second},
+		{5, 2 * time.Second},
+		{9, 32 * time.Second},
+		{10, 15 * time.Minute},
+		{25, 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := policy.LockFor(tt.failures); got != tt.want {
+			t.Errorf("LockFor(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+
+	// 倍々に延ばした待ち時間はロックアウト期間を超えない
+	short := LockoutPolicy{FreeAttempts: 0, BaseDelay: time.Minute, MaxAttempts: 100, LockoutDuration: 5 * time.Minute}
+	if got := short.LockFor(50); got != 5*time.Minute {
+		t.Errorf("LockFor(50) = %v, want the lockout duration", got)
+	}
+}
+
+func TestUserRecordFailedLogin(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	policy := LockoutPolicy{FreeAttempts: 3, BaseDelay: time.Second, MaxAttempts: 10, LockoutDuration: 15 * time.Minute}
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+
+	user.RecordFailedLogin(3, policy, now)
+	if user.IsLocked(now) {
+		t.Error("Expected no delay within the free attempts")
+	}
+
+	user.RecordFailedLogin(10, policy, now)
+	if !user.IsLocked(now.Add(14 * time.Minute)) {
+		t.Error("Expected the account to be locked after the maximum attempts")
+	}
+	if user.IsLocked(now.Add(15 * time.Minute)) {
+		t.Error("Expected the lock to expire after the lockout duration")
+	}
+
+	user.ClearFailedLogins()
+	if user.IsLocked(now) || user.FailedAttempts != 0 {
+		t.Error("Expected ClearFailedLogins() to reset the counter and unlock")
+	}
+}
@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"crypto/rand"
+	"strings"
+	"time"
+)
+
+// RecoveryCodeCount 2段階認証を有効にしたときに発行するリカバリーコードの数
+const RecoveryCodeCount = 10
+
+// MaxMFAAttempts 1つの MFA チャレンジでコードを確認できる回数の上限
+const MaxMFAAttempts = 5
+
+// RecoveryCode 認証アプリを使えないときに TOTP の代わりに使う1回限りのコード
+// 平文は有効化したときに一度だけ返し、サーバーには SHA-256 ハッシュを保存する
+type RecoveryCode struct {
+	ID        uint       `json:"-" gorm:"primaryKey"`
+	UserID    uint       `json:"-" gorm:"not null;index"`
+	CodeHash  string     `json:"-" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"-"`
+}
+
+// recoveryCodeAlphabet 読み間違えやすい文字（0/O、1/I/L）を除いた文字
+const recoveryCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// NewRecoveryCodes リカバリーコードを RecoveryCodeCount 個作成し、保存用のエンティティと平文（xxxxx-xxxxx 形式）を返す
+func NewRecoveryCodes(userID uint) ([]*RecoveryCode, []string, error) {
+	codes := make([]*RecoveryCode, 0, RecoveryCodeCount)
+	plaintexts := make([]string, 0, RecoveryCodeCount)
+	for i := 0; i < RecoveryCodeCount; i++ {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		for j := range b {
+			b[j] = recoveryCodeAlphabet[int(b[j])%len(recoveryCodeAlphabet)]
+		}
+
+		plaintext := string(b[:5]) + "-" + string(b[5:])
+		codes = append(codes, &RecoveryCode{UserID: userID, CodeHash: HashRecoveryCode(plaintext)})
+		plaintexts = append(plaintexts, plaintext)
+	}
+	return codes, plaintexts, nil
+}
+
+// HashRecoveryCode 大文字・小文字と区切り文字の違いを無視してリカバリーコードをハッシュ化
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return HashToken(normalized)
+}
+
+// MFAChallenge パスワードを確認したあと、2段階認証のコードと引き換えにトークンを発行するためのチャレンジ
+// 平文はログインのレスポンスでのみ返し、サーバーには SHA-256 ハッシュを保存する
+// 1回使うか、有効期限を過ぎるか、コードを MaxMFAAttempts 回確認すると使えなくなる
+// Attempts は確認の前にデータベース上で予約した回数で、同時に送られたコードも上限を超えて確認しない
+type MFAChallenge struct {
+	ID             uint       `json:"-" gorm:"primaryKey"`
+	UserID         uint       `json:"-" gorm:"not null;index"`
+	OrganizationID uint       `json:"-" gorm:"not null"`
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt      time.Time  `json:"-" gorm:"not null;index"`
+	Attempts       int        `json:"-" gorm:"not null;default:0"`
+	UsedAt         *time.Time `json:"-"`
+	CreatedAt      time.Time  `json:"-"`
+}
+
+// NewMFAChallenge MFA チャレンジを作成し、保存用のエンティティと平文を返す
+func NewMFAChallenge(userID, organizationID uint, now time.Time, ttl time.Duration) (*MFAChallenge, string, error) {
+	if userID == 0 || organizationID == 0 || ttl <= 0 {
+		return nil, "", ErrInvalidMFAChallenge
+	}
+
+	plaintext, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &MFAChallenge{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		TokenHash:      HashToken(plaintext),
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}, plaintext, nil
+}
+
+// Valid まだコードを受け付けられるかチェック
+func (c *MFAChallenge) Valid(now time.Time) error {
+	if c.UsedAt != nil || !now.Before(c.ExpiresAt) || c.Attempts >= MaxMFAAttempts {
+		return ErrInvalidMFAChallenge
+	}
+	return nil
+}
+
+// Use MFA チャレンジを使用済みにする
+func (c *MFAChallenge) Use(now time.Time) error {
+	if err := c.Valid(now); err != nil {
+		return err
+	}
+	c.UsedAt = &now
+	return nil
+}
@@ -0,0 +1,69 @@
+package domain
+
+import (
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultOrganizationSlug 既存データの移行先で、新規登録したユーザーが所属する組織
+const DefaultOrganizationSlug = "default"
+
+var organizationSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// Organization テナント（部署など）
+// リソースと予約は組織ごとに分離され、他の組織からは参照できない
+type Organization struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// NewOrganization 組織を作成
+// slug は英小文字・数字・ハイフンのみ（最大63文字）
+func NewOrganization(name, slug string) (*Organization, error) {
+	name = strings.TrimSpace(name)
+	slug = strings.ToLower(strings.TrimSpace(slug))
+	if name == "" || len(slug) > 63 || !organizationSlugPattern.MatchString(slug) {
+		return nil, ErrInvalidOrganization
+	}
+
+	return &Organization{
+		Name: name,
+		Slug: slug,
+	}, nil
+}
+
+// Membership ユーザーの組織への所属
+// 権限は所属ごとに持ち、ある組織の管理者が別の組織でも管理者になるとは限らない
+type Membership struct {
+	ID             uint      `json:"-" gorm:"primaryKey"`
+	OrganizationID uint      `json:"organization_id" gorm:"not null;uniqueIndex:idx_membership"`
+	UserID         uint      `json:"user_id" gorm:"not null;uniqueIndex:idx_membership"`
+	Role           Role      `json:"role" gorm:"not null;default:'member'"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// NewMembership ユーザーを組織に所属させる
+func NewMembership(organizationID, userID uint) (*Membership, error) {
+	if organizationID == 0 || userID == 0 {
+		return nil, ErrInvalidMembership
+	}
+
+	return &Membership{
+		OrganizationID: organizationID,
+		UserID:         userID,
+		Role:           RoleMember,
+	}, nil
+}
+
+// SetRole 組織での権限を変更
+func (m *Membership) SetRole(role Role) error {
+	if _, err := ParseRole(string(role)); err != nil {
+		return err
+	}
+	m.Role = role
+	return nil
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "testing"
+
+func TestNewOrganization(t *testing.T) {
+	tests := []struct {
+		name     string
+		orgName  string
+		slug     string
+		wantSlug string
+		wantErr  error
+	}{
+		{"Valid organization", "Sales", "sales", "sales", nil},
+		{"Slug is lowercased", "Tokyo Office", " Tokyo-Office ", "tokyo-office", nil},
+		{"Empty name", " ", "sales", "", ErrInvalidOrganization},
+		{"Empty slug", "Sales", "", "", ErrInvalidOrganization},
+		{"Slug with spaces", "Sales", "sales team", "", ErrInvalidOrganization},
+		{"Slug with leading hyphen", "Sales", "-sales", "", ErrInvalidOrganization},
+		{"Slug with double hyphen", "Sales", "sales--team", "", ErrInvalidOrganization},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			organization, err := NewOrganization(tt.orgName, tt.slug)
+			if err != tt.wantErr {
+				t.Fatalf("NewOrganization() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && organization.Slug != tt.wantSlug {
+				t.Errorf("Slug = %q, want %q", organization.Slug, tt.wantSlug)
+			}
+		})
+	}
+}
+
+func TestNewMembership(t *testing.T) {
+	if _, err := NewMembership(1, 2); err != nil {
+		t.Errorf("NewMembership() error = %v", err)
+	}
+	if _, err := NewMembership(0, 2); err != ErrInvalidMembership {
+		t.Errorf("Expected ErrInvalidMembership without an organization, got %v", err)
+	}
+	if _, err := NewMembership(1, 0); err != ErrInvalidMembership {
+		t.Errorf("Expected ErrInvalidMembership without a user, got %v", err)
+	}
+}
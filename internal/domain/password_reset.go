@@ -0,0 +1,43 @@
+package domain
+
+import "time"
+
+// PasswordResetToken パスワード再設定トークン
+// 平文はメールでのみ送り、サーバーには SHA-256 ハッシュを保存する
+// 1回使うか有効期限を過ぎると使えなくなり、新しいトークンを発行すると以前のトークンも使えなくなる
+type PasswordResetToken struct {
+	ID        uint       `json:"-" gorm:"primaryKey"`
+	UserID    uint       `json:"-" gorm:"not null;index"`
+	TokenHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time  `json:"-" gorm:"not null;index"`
+	UsedAt    *time.Time `json:"-"`
+	CreatedAt time.Time  `json:"-"`
+}
+
+// NewPasswordResetToken パスワード再設定トークンを作成し、保存用のエンティティと平文を返す
+func NewPasswordResetToken(userID uint, now time.Time, ttl time.Duration) (*PasswordResetToken, string, error) {
+	if userID == 0 || ttl <= 0 {
+		return nil, "", ErrInvalidResetToken
+	}
+
+	plaintext, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &PasswordResetToken{
+		UserID:    userID,
+		TokenHash: HashToken(plaintext),
+		ExpiresAt: now.Add(ttl),
+	}, plaintext, nil
+}
+
+// Use パスワード再設定トークンを使用済みにする
+func (t *PasswordResetToken) Use(now time.Time) error {
+	if t.UsedAt != nil || !now.Before(t.ExpiresAt) {
+		return ErrInvalidResetToken
+	}
+
+	t.UsedAt = &now
+	return nil
+}
@@ -0,0 +1,34 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPasswordResetTokenUse(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, plaintext, err := NewPasswordResetToken(1, now, time.Hour)
+	if err != nil {
+		t.Fatalf("NewPasswordResetToken() error = %v", err)
+	}
+	if token.TokenHash != HashToken(plaintext) || token.TokenHash == plaintext {
+		t.Error("Expected only the hash of the plaintext token to be stored")
+	}
+
+	if err := token.Use(now.Add(59 * time.Minute)); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := token.Use(now.Add(59 * time.Minute)); err != ErrInvalidResetToken {
+		t.Errorf("Expected ErrInvalidResetToken on second use, got %v", err)
+	}
+
+	expired, _, _ := NewPasswordResetToken(1, now, time.Hour)
+	if err := expired.Use(now.Add(time.Hour)); err != ErrInvalidResetToken {
+		t.Errorf("Expected ErrInvalidResetToken after expiry, got %v", err)
+	}
+
+	if _, _, err := NewPasswordResetToken(0, now, time.Hour); err != ErrInvalidResetToken {
+		t.Errorf("Expected ErrInvalidResetToken without a user, got %v", err)
+	}
+}
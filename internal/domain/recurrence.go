@@ -0,0 +1,265 @@
+package domain
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// MaxRecurrenceOccurrences 1つの繰り返しルールから生成できる最大回数
+const MaxRecurrenceOccurrences = 366
+
+// maxRecurrencePeriods 一致する日がないルールで無限ループしないための上限
+const maxRecurrencePeriods = 10000
+
+// RecurrenceFrequency 繰り返し頻度（RFC 5545 FREQ）
+type RecurrenceFrequency string
+
+const (
+	FrequencyDaily   RecurrenceFrequency = "DAILY"
+	FrequencyWeekly  RecurrenceFrequency = "WEEKLY"
+	FrequencyMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// RecurrenceDay BYDAY の要素（Ordinal は MONTHLY のみ。0 は月内の全該当曜日）
+type RecurrenceDay struct {
+	Ordinal int
+	Weekday time.Weekday
+}
+
+// RecurrenceRule RFC 5545 RRULE のサブセット（FREQ, INTERVAL, COUNT, UNTIL, BYDAY）
+type RecurrenceRule struct {
+	Frequency RecurrenceFrequency
+	Interval  int
+	Count     int
+	Until     *time.Time
+	ByDay     []RecurrenceDay
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// ParseRecurrenceRule "FREQ=WEEKLY;BYDAY=TU;COUNT=10" 形式のルールをパース
+// 無限に続くルールは受け付けないため COUNT か UNTIL のどちらかが必須
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, ErrInvalidRecurrenceRule
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" || seen[key] {
+			return nil, ErrInvalidRecurrenceRule
+		}
+		seen[key] = true
+
+		switch key {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(val)
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			rule.Interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n <= 0 || n > MaxRecurrenceOccurrences {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			rule.Count = n
+		case "UNTIL":
+			until, err := parseUntil(val)
+			if err != nil {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			rule.Until = &until
+		case "BYDAY":
+			days, err := parseByDay(val)
+			if err != nil {
+				return nil, err
+			}
+			rule.ByDay = days
+		default:
+			return nil, ErrInvalidRecurrenceRule
+		}
+	}
+
+	switch rule.Frequency {
+	case FrequencyDaily, FrequencyWeekly, FrequencyMonthly:
+	default:
+		return nil, ErrInvalidRecurrenceRule
+	}
+	if (rule.Count == 0) == (rule.Until == nil) {
+		return nil, ErrInvalidRecurrenceRule
+	}
+	if rule.Frequency != FrequencyMonthly {
+		for _, day := range rule.ByDay {
+			if day.Ordinal != 0 {
+				return nil, ErrInvalidRecurrenceRule
+			}
+		}
+	}
+
+	return rule, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	return time.Parse("20060102", value)
+}
+
+func parseByDay(value string) ([]RecurrenceDay, error) {
+	var days []RecurrenceDay
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return nil, ErrInvalidRecurrenceRule
+		}
+		weekday, ok := weekdayCodes[item[len(item)-2:]]
+		if !ok {
+			return nil, ErrInvalidRecurrenceRule
+		}
+
+		ordinal := 0
+		if prefix := item[:len(item)-2]; prefix != "" {
+			n, err := strconv.Atoi(prefix)
+			if err != nil || n == 0 || n < -5 || n > 5 {
+				return nil, ErrInvalidRecurrenceRule
+			}
+			ordinal = n
+		}
+
+		days = append(days, RecurrenceDay{Ordinal: ordinal, Weekday: weekday})
+	}
+	return days, nil
+}
+
+// Occurrences start 以降でルールに一致する日付を生成
+// start 自体もルールに一致する場合のみ含める
+func (r *RecurrenceRule) Occurrences(start time.Time) ([]time.Time, error) {
+	start = time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+
+	var occurrences []time.Time
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.candidates(start, period)
+		if len(candidates) == 0 {
+			continue
+		}
+
+		for _, date := range candidates {
+			if date.Before(start) {
+				continue
+			}
+			if r.Until != nil && date.After(*r.Until) {
+				return occurrences, nil
+			}
+			if len(occurrences) == MaxRecurrenceOccurrences {
+				return nil, ErrTooManyOccurrences
+			}
+			occurrences = append(occurrences, date)
+			if r.Count > 0 && len(occurrences) == r.Count {
+				return occurrences, nil
+			}
+		}
+	}
+
+	return occurrences, nil
+}
+
+// candidates period 番目の期間（日・週・月）に含まれる候補日を昇順で返す
+func (r *RecurrenceRule) candidates(start time.Time, period int) []time.Time {
+	step := period * r.Interval
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		date := start.AddDate(0, 0, step)
+		if len(r.ByDay) > 0 && !r.matchesWeekday(date.Weekday()) {
+			return nil
+		}
+		return []time.Time{date}
+
+	case FrequencyWeekly:
+		// 週の始まりは月曜日（RFC 5545 の WKST 既定値）
+		offset := (int(start.Weekday()) + 6) % 7
+		weekStart := start.AddDate(0, 0, -offset+7*step)
+		var dates []time.Time
+		for i := 0; i < 7; i++ {
+			date := weekStart.AddDate(0, 0, i)
+			if (len(r.ByDay) == 0 && date.Weekday() == start.Weekday()) || r.matchesWeekday(date.Weekday()) {
+				dates = append(dates, date)
+			}
+		}
+		return dates
+
+	case FrequencyMonthly:
+		monthStart := time.Date(start.Year(), start.Month()+time.Month(step), 1, 0, 0, 0, 0, start.Location())
+		if len(r.ByDay) == 0 {
+			date := monthStart.AddDate(0, 0, start.Day()-1)
+			// 存在しない日付（2月30日など）はスキップ
+			if date.Month() != monthStart.Month() {
+				return nil
+			}
+			return []time.Time{date}
+		}
+		return r.monthlyByDay(monthStart)
+	}
+
+	return nil
+}
+
+func (r *RecurrenceRule) monthlyByDay(monthStart time.Time) []time.Time {
+	var byWeekday [7][]time.Time
+	for date := monthStart; date.Month() == monthStart.Month(); date = date.AddDate(0, 0, 1) {
+		byWeekday[date.Weekday()] = append(byWeekday[date.Weekday()], date)
+	}
+
+	seen := make(map[int]bool)
+	var dates []time.Time
+	add := func(date time.Time) {
+		if !seen[date.Day()] {
+			seen[date.Day()] = true
+			dates = append(dates, date)
+		}
+	}
+
+	for _, day := range r.ByDay {
+		matches := byWeekday[day.Weekday]
+		switch {
+		case day.Ordinal == 0:
+			for _, date := range matches {
+				add(date)
+			}
+		case day.Ordinal > 0 && day.Ordinal <= len(matches):
+			add(matches[day.Ordinal-1])
+		case day.Ordinal < 0 && -day.Ordinal <= len(matches):
+			add(matches[len(matches)+day.Ordinal])
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool {
+		return dates[i].Before(dates[j])
+	})
+	return dates
+}
+
+func (r *RecurrenceRule) matchesWeekday(weekday time.Weekday) bool {
+	for _, day := range r.ByDay {
+		if day.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
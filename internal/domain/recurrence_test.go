@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceRule(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "Weekly with count", rule: "FREQ=WEEKLY;BYDAY=TU;COUNT=10", wantErr: false},
+		{name: "RRULE prefix", rule: "RRULE:FREQ=DAILY;COUNT=5", wantErr: false},
+		{name: "Daily until date", rule: "FREQ=DAILY;UNTIL=20260131", wantErr: false},
+		{name: "Until date-time", rule: "FREQ=WEEKLY;UNTIL=20260131T235959Z", wantErr: false},
+		{name: "Monthly second Tuesday", rule: "FREQ=MONTHLY;BYDAY=2TU;COUNT=6", wantErr: false},
+		{name: "Missing frequency", rule: "COUNT=5", wantErr: true},
+		{name: "Unsupported frequency", rule: "FREQ=YEARLY;COUNT=5", wantErr: true},
+		{name: "Unbounded rule", rule: "FREQ=DAILY", wantErr: true},
+		{name: "Both count and until", rule: "FREQ=DAILY;COUNT=5;UNTIL=20260131", wantErr: true},
+		{name: "Invalid weekday", rule: "FREQ=WEEKLY;BYDAY=XX;COUNT=5", wantErr: true},
+		{name: "Ordinal outside monthly", rule: "FREQ=WEEKLY;BYDAY=1MO;COUNT=5", wantErr: true},
+		{name: "Zero interval", rule: "FREQ=DAILY;INTERVAL=0;COUNT=5", wantErr: true},
+		{name: "Count too large", rule: "FREQ=DAILY;COUNT=1000", wantErr: true},
+		{name: "Unknown part", rule: "FREQ=DAILY;COUNT=5;BYHOUR=9", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRecurrenceRule() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if rule == nil && !tt.wantErr {
+				t.Error("ParseRecurrenceRule() returned nil rule")
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	// 2026-01-05 は月曜日
+	start := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+		want []string
+	}{
+		{
+			name: "Every Tuesday",
+			rule: "FREQ=WEEKLY;BYDAY=TU;COUNT=3",
+			want: []string{"2026-01-06", "2026-01-13", "2026-01-20"},
+		},
+		{
+			name: "Weekly defaults to start weekday",
+			rule: "FREQ=WEEKLY;COUNT=2",
+			want: []string{"2026-01-05", "2026-01-12"},
+		},
+		{
+			name: "Every other week on Monday and Friday",
+			rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR;COUNT=4",
+			want: []string{"2026-01-05", "2026-01-09", "2026-01-19", "2026-01-23"},
+		},
+		{
+			name: "Weekdays until Friday",
+			rule: "FREQ=DAILY;BYDAY=MO,TU,WE,TH,FR;UNTIL=20260112",
+			want: []string{"2026-01-05", "2026-01-06", "2026-01-07", "2026-01-08", "2026-01-09", "2026-01-12"},
+		},
+		{
+			name: "Monthly on the same day",
+			rule: "FREQ=MONTHLY;COUNT=3",
+			want: []string{"2026-01-05", "2026-02-05", "2026-03-05"},
+		},
+		{
+			name: "Monthly on the second Tuesday",
+			rule: "FREQ=MONTHLY;BYDAY=2TU;COUNT=3",
+			want: []string{"2026-01-13", "2026-02-10", "2026-03-10"},
+		},
+		{
+			name: "Monthly on the last Friday",
+			rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=2",
+			want: []string{"2026-01-30", "2026-02-27"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule() error = %v", err)
+			}
+
+			dates, err := rule.Occurrences(start)
+			if err != nil {
+				t.Fatalf("Occurrences() error = %v", err)
+			}
+
+			if len(dates) != len(tt.want) {
+				t.Fatalf("Occurrences() returned %d dates, want %d", len(dates), len(tt.want))
+			}
+			for i, date := range dates {
+				if got := date.Format("2006-01-02"); got != tt.want[i] {
+					t.Errorf("Occurrence %d = %s, want %s", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRecurrenceRuleMonthlySkipsMissingDays(t *testing.T) {
+	start := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	rule, _ := ParseRecurrenceRule("FREQ=MONTHLY;COUNT=3")
+
+	dates, err := rule.Occurrences(start)
+	if err != nil {
+		t.Fatalf("Occurrences() error = %v", err)
+	}
+
+	want := []string{"2026-01-31", "2026-03-31", "2026-05-31"}
+	for i, date := range dates {
+		if got := date.Format("2006-01-02"); got != want[i] {
+			t.Errorf("Occurrence %d = %s, want %s", i, got, want[i])
+		}
+	}
+}
+
+func TestRecurrenceRuleTooManyOccurrences(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rule, _ := ParseRecurrenceRule("FREQ=DAILY;UNTIL=20300101")
+
+	if _, err := rule.Occurrences(start); err != ErrTooManyOccurrences {
+		t.Errorf("Expected ErrTooManyOccurrences, got %v", err)
+	}
+}
@@ -11,48 +11,221 @@ const (
 	StatusPending   ReservationStatus = "pending"
 	StatusConfirmed ReservationStatus = "confirmed"
 	StatusCancelled ReservationStatus = "cancelled"
+	StatusExpired   ReservationStatus = "expired"
+	StatusCheckedIn ReservationStatus = "checked_in"
+	StatusCompleted ReservationStatus = "completed"
+	StatusNoShow    ReservationStatus = "no_show"
+	StatusRejected  ReservationStatus = "rejected"
 )
 
+// ActiveStatuses 定員を消費するステータス
+var ActiveStatuses = []ReservationStatus{StatusPending, StatusConfirmed, StatusCheckedIn}
+
+// reservationTransitions 許可されるステータス遷移
+var reservationTransitions = map[ReservationStatus][]ReservationStatus{
+	StatusPending:   {StatusConfirmed, StatusCancelled, StatusExpired, StatusRejected},
+	StatusConfirmed: {StatusCheckedIn, StatusCancelled, StatusNoShow},
+	StatusCheckedIn: {StatusCompleted},
+}
+
+// CanTransitionTo next への遷移が許可されているかチェック
+func (s ReservationStatus) CanTransitionTo(next ReservationStatus) bool {
+	for _, allowed := range reservationTransitions[s] {
+		if next == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// IsActive 定員を消費するステータスかチェック
+func (s ReservationStatus) IsActive() bool {
+	for _, active := range ActiveStatuses {
+		if s == active {
+			return true
+		}
+	}
+	return false
+}
+
 // Reservation 予約エンティティ（集約ルート）
 type Reservation struct {
-	ID        uint              `json:"id" gorm:"primaryKey"`
-	UserID    uint              `json:"user_id" gorm:"not null"`
-	TimeSlot  *TimeSlot         `json:"time_slot" gorm:"embedded"`
-	Status    ReservationStatus `json:"status" gorm:"not null;default:'pending'"`
-	CreatedAt time.Time         `json:"created_at"`
-	UpdatedAt time.Time         `json:"updated_at"`
+	ID             uint              `json:"id" gorm:"primaryKey"`
+	OrganizationID uint              `json:"organization_id" gorm:"index"`
+	UserID         uint              `json:"user_id" gorm:"not null"`
+	ResourceID     uint              `json:"resource_id" gorm:"not null;index"`
+	SeriesID       *uint             `json:"series_id,omitempty" gorm:"index"`
+	TimeSlot       *TimeSlot         `json:"time_slot" gorm:"embedded"`
+	Quantity       int               `json:"quantity" gorm:"not null;default:1"`
+	Status         ReservationStatus `json:"status" gorm:"not null;default:'pending'"`
+
+	CancellationFeePercent int    `json:"cancellation_fee_percent,omitempty"`
+	CancellationReason     string `json:"cancellation_reason,omitempty"`
+
+	AwaitingApproval bool   `json:"awaiting_approval" gorm:"not null;default:false;index"`
+	ApprovedBy       *uint  `json:"approved_by,omitempty"`
+	RejectedBy       *uint  `json:"rejected_by,omitempty"`
+	RejectionReason  string `json:"rejection_reason,omitempty"`
+
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+	CancelledAt *time.Time `json:"cancelled_at,omitempty"`
+	ExpiredAt   *time.Time `json:"expired_at,omitempty"`
+	CheckedInAt *time.Time `json:"checked_in_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	NoShowAt    *time.Time `json:"no_show_at,omitempty"`
+	RejectedAt  *time.Time `json:"rejected_at,omitempty"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 // NewReservation 新規予約を作成
-func NewReservation(userID uint, timeSlot *TimeSlot) (*Reservation, error) {
+// quantity は確保する席数
+func NewReservation(userID, resourceID uint, timeSlot *TimeSlot, quantity int) (*Reservation, error) {
 	if userID == 0 {
 		return nil, ErrInvalidUser
 	}
+	if resourceID == 0 {
+		return nil, ErrInvalidResource
+	}
 	if timeSlot == nil {
 		return nil, ErrInvalidTimeSlot
 	}
+	if quantity <= 0 {
+		return nil, ErrInvalidQuantity
+	}
+	if quantity > timeSlot.Capacity {
+		return nil, ErrCapacityExceeded
+	}
 
 	return &Reservation{
-		UserID:   userID,
-		TimeSlot: timeSlot,
-		Status:   StatusPending,
+		UserID:     userID,
+		ResourceID: resourceID,
+		TimeSlot:   timeSlot,
+		Quantity:   quantity,
+		Status:     StatusPending,
 	}, nil
 }
 
+// transition 遷移表に従ってステータスを変更し、遷移時刻を記録
+func (r *Reservation) transition(next ReservationStatus, now time.Time) error {
+	if !r.Status.CanTransitionTo(next) {
+		return ErrInvalidStatusTransition
+	}
+
+	r.Status = next
+	switch next {
+	case StatusConfirmed:
+		r.ConfirmedAt = &now
+	case StatusCancelled:
+		r.CancelledAt = &now
+	case StatusExpired:
+		r.ExpiredAt = &now
+	case StatusCheckedIn:
+		r.CheckedInAt = &now
+	case StatusCompleted:
+		r.CompletedAt = &now
+	case StatusNoShow:
+		r.NoShowAt = &now
+	case StatusRejected:
+		r.RejectedAt = &now
+	}
+	return nil
+}
+
 // Confirm 予約を確定
-func (r *Reservation) Confirm() error {
+// 承認待ちの仮予約は承認者の Approve でのみ確定できる
+func (r *Reservation) Confirm(now time.Time) error {
 	if r.Status != StatusPending {
 		return ErrReservationNotPending
 	}
-	r.Status = StatusConfirmed
-	return nil
+	if r.AwaitingApproval {
+		return ErrApprovalRequired
+	}
+	return r.transition(StatusConfirmed, now)
+}
+
+// CanCancel 仮予約・確定済み予約のうち、キャンセルポリシーが許可するものだけキャンセル可能
+func (r *Reservation) CanCancel(now time.Time, policy CancellationPolicy) bool {
+	if !r.Status.CanTransitionTo(StatusCancelled) {
+		return false
+	}
+	_, err := policy.Evaluate(r.TimeSlot.StartAt, now)
+	return err == nil
 }
 
-// Cancel 予約をキャンセル
-func (r *Reservation) Cancel() error {
+// Cancel キャンセルポリシーを開始日時に対して評価し、適用されたキャンセル料と理由を記録してキャンセル
+func (r *Reservation) Cancel(now time.Time, policy CancellationPolicy) error {
 	if r.Status == StatusCancelled {
 		return ErrReservationAlreadyCancelled
 	}
-	r.Status = StatusCancelled
+	if !r.Status.CanTransitionTo(StatusCancelled) {
+		return ErrInvalidStatusTransition
+	}
+
+	fee, err := policy.Evaluate(r.TimeSlot.StartAt, now)
+	if err != nil {
+		return err
+	}
+
+	r.CancellationFeePercent = fee.Percent
+	r.CancellationReason = fee.Reason
+	return r.transition(StatusCancelled, now)
+}
+
+// CheckIn 確定済み予約をチェックイン（終了時刻まで可能）
+func (r *Reservation) CheckIn(now time.Time) error {
+	if r.Status == StatusConfirmed && !now.Before(r.TimeSlot.EndAt) {
+		return ErrReservationEnded
+	}
+	return r.transition(StatusCheckedIn, now)
+}
+
+// Complete チェックイン済み予約を利用完了にする
+func (r *Reservation) Complete(now time.Time) error {
+	return r.transition(StatusCompleted, now)
+}
+
+// MarkNoShow 開始から grace を過ぎてもチェックインのない確定済み予約を無断キャンセルにする
+func (r *Reservation) MarkNoShow(now time.Time, grace time.Duration) error {
+	if r.Status == StatusConfirmed && now.Before(r.TimeSlot.StartAt.Add(grace)) {
+		return ErrNoShowGraceNotElapsed
+	}
+	return r.transition(StatusNoShow, now)
+}
+
+// Reschedule 予約を別の時間枠へ移動
+// 定員の確認はリポジトリで移動先の時間枠に対して行う
+func (r *Reservation) Reschedule(timeSlot *TimeSlot) error {
+	if r.Status != StatusPending && r.Status != StatusConfirmed {
+		return ErrReservationNotActive
+	}
+	if timeSlot == nil {
+		return ErrInvalidTimeSlot
+	}
+	if r.Quantity > timeSlot.Capacity {
+		return ErrCapacityExceeded
+	}
+	r.TimeSlot = timeSlot
 	return nil
 }
+
+// IsHoldExpired 仮予約の保持期限（作成から ttl）を過ぎているかチェック
+// ttl が0以下の場合や、承認待ちの仮予約は期限なし
+func (r *Reservation) IsHoldExpired(now time.Time, ttl time.Duration) bool {
+	if r.Status != StatusPending || r.AwaitingApproval || ttl <= 0 {
+		return false
+	}
+	return !now.Before(r.CreatedAt.Add(ttl))
+}
+
+// Expire 保持期限を過ぎた仮予約を期限切れにし、定員を解放する
+func (r *Reservation) Expire(now time.Time, ttl time.Duration) error {
+	if r.Status != StatusPending {
+		return ErrReservationNotPending
+	}
+	if !r.IsHoldExpired(now, ttl) {
+		return ErrHoldNotExpired
+	}
+	return r.transition(StatusExpired, now)
+}
@@ -10,34 +10,74 @@ func TestNewReservation(t *testing.T) {
 	ts, _ := NewTimeSlot(date, "09:00", "10:00", 10)
 
 	tests := []struct {
-		name     string
-		userID   uint
-		timeSlot *TimeSlot
-		wantErr  bool
+		name       string
+		userID     uint
+		resourceID uint
+		timeSlot   *TimeSlot
+		quantity   int
+		wantErr    bool
 	}{
 		{
-			name:     "Valid reservation",
-			userID:   1,
-			timeSlot: ts,
-			wantErr:  false,
+			name:       "Valid reservation",
+			userID:     1,
+			resourceID: 1,
+			timeSlot:   ts,
+			quantity:   1,
+			wantErr:    false,
 		},
 		{
-			name:     "Invalid user ID",
-			userID:   0,
-			timeSlot: ts,
-			wantErr:  true,
+			name:       "Invalid user ID",
+			userID:     0,
+			resourceID: 1,
+			timeSlot:   ts,
+			quantity:   1,
+			wantErr:    true,
 		},
 		{
-			name:     "Nil time slot",
-			userID:   1,
-			timeSlot: nil,
-			wantErr:  true,
+			name:       "Invalid resource ID",
+			userID:     1,
+			resourceID: 0,
+			timeSlot:   ts,
+			quantity:   1,
+			wantErr:    true,
+		},
+		{
+			name:       "Nil time slot",
+			userID:     1,
+			resourceID: 1,
+			timeSlot:   nil,
+			quantity:   1,
+			wantErr:    true,
+		},
+		{
+			name:       "Party of four",
+			userID:     1,
+			resourceID: 1,
+			timeSlot:   ts,
+			quantity:   4,
+			wantErr:    false,
+		},
+		{
+			name:       "Zero quantity",
+			userID:     1,
+			resourceID: 1,
+			timeSlot:   ts,
+			quantity:   0,
+			wantErr:    true,
+		},
+		{
+			name:       "Quantity above capacity",
+			userID:     1,
+			resourceID: 1,
+			timeSlot:   ts,
+			quantity:   11,
+			wantErr:    true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			reservation, err := NewReservation(tt.userID, tt.timeSlot)
+			reservation, err := NewReservation(tt.userID, tt.resourceID, tt.timeSlot, tt.quantity)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("NewReservation() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -48,3 +88,139 @@ func TestNewReservation(t *testing.T) {
 		})
 	}
 }
+
+func TestReservationExpire(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Now(), "09:00", "10:00", 10)
+	createdAt := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	ttl := 15 * time.Minute
+
+	newPending := func() *Reservation {
+		reservation, _ := NewReservation(1, 1, ts, 1)
+		reservation.CreatedAt = createdAt
+		return reservation
+	}
+
+	reservation := newPending()
+	if err := reservation.Expire(createdAt.Add(14*time.Minute), ttl); err != ErrHoldNotExpired {
+		t.Errorf("Expected ErrHoldNotExpired within TTL, got %v", err)
+	}
+	if err := reservation.Expire(createdAt.Add(ttl), ttl); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if reservation.Status != StatusExpired || reservation.Status.IsActive() {
+		t.Errorf("Expected inactive expired status, got %s", reservation.Status)
+	}
+
+	confirmed := newPending()
+	_ = confirmed.Confirm(createdAt)
+	if err := confirmed.Expire(createdAt.Add(time.Hour), ttl); err != ErrReservationNotPending {
+		t.Errorf("Expected ErrReservationNotPending for confirmed reservation, got %v", err)
+	}
+
+	if newPending().IsHoldExpired(createdAt.Add(24*time.Hour), 0) {
+		t.Error("Hold should never expire when TTL is disabled")
+	}
+}
+
+func TestReservationReschedule(t *testing.T) {
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := NewTimeSlot(date, "09:00", "10:00", 10)
+	target, _ := NewTimeSlot(date.AddDate(0, 0, 1), "13:00", "14:00", 10)
+
+	reservation, _ := NewReservation(1, 1, ts, 3)
+	if err := reservation.Reschedule(target); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if reservation.TimeSlot != target || reservation.Status != StatusPending {
+		t.Errorf("Expected pending reservation on target slot, got %+v (%s)", reservation.TimeSlot, reservation.Status)
+	}
+
+	small, _ := NewTimeSlot(date, "15:00", "16:00", 2)
+	if err := reservation.Reschedule(small); err != ErrCapacityExceeded {
+		t.Errorf("Expected ErrCapacityExceeded, got %v", err)
+	}
+	if err := reservation.Reschedule(nil); err != ErrInvalidTimeSlot {
+		t.Errorf("Expected ErrInvalidTimeSlot, got %v", err)
+	}
+
+	_ = reservation.Cancel(date, nil)
+	if err := reservation.Reschedule(target); err != ErrReservationNotActive {
+		t.Errorf("Expected ErrReservationNotActive, got %v", err)
+	}
+}
+
+func TestReservationStatusTransitions(t *testing.T) {
+	tests := []struct {
+		from ReservationStatus
+		to   ReservationStatus
+		want bool
+	}{
+		{StatusPending, StatusConfirmed, true},
+		{StatusPending, StatusCheckedIn, false},
+		{StatusConfirmed, StatusCheckedIn, true},
+		{StatusConfirmed, StatusNoShow, true},
+		{StatusConfirmed, StatusExpired, false},
+		{StatusCheckedIn, StatusCompleted, true},
+		{StatusCheckedIn, StatusCancelled, false},
+		{StatusCompleted, StatusCancelled, false},
+		{StatusNoShow, StatusCheckedIn, false},
+		{StatusCancelled, StatusConfirmed, false},
+	}
+
+	for _, tt := range tests {
+		if got := tt.from.CanTransitionTo(tt.to); got != tt.want {
+			t.Errorf("%s -> %s = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestReservationLifecycle(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 10)
+	start := ts.StartAt
+
+	newConfirmed := func() *Reservation {
+		reservation, _ := NewReservation(1, 1, ts, 1)
+		_ = reservation.Confirm(start.Add(-24 * time.Hour))
+		return reservation
+	}
+
+	reservation := newConfirmed()
+	if reservation.ConfirmedAt == nil {
+		t.Error("Expected ConfirmedAt to be recorded")
+	}
+	if err := reservation.Cancel(start, nil); err != ErrCancellationNotAllowed {
+		t.Errorf("Expected ErrCancellationNotAllowed, got %v", err)
+	}
+	if err := reservation.CheckIn(start.Add(5 * time.Minute)); err != nil {
+		t.Fatalf("CheckIn() error = %v", err)
+	}
+	if !reservation.Status.IsActive() || reservation.CheckedInAt == nil {
+		t.Errorf("Expected active checked-in reservation, got %s", reservation.Status)
+	}
+	if err := reservation.Complete(start.Add(time.Hour)); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if reservation.Status != StatusCompleted || reservation.CompletedAt == nil {
+		t.Errorf("Expected completed reservation, got %s", reservation.Status)
+	}
+	if err := reservation.Cancel(start.Add(2*time.Hour), nil); err != ErrInvalidStatusTransition {
+		t.Errorf("Expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	late := newConfirmed()
+	if err := late.CheckIn(start.Add(time.Hour)); err != ErrReservationEnded {
+		t.Errorf("Expected ErrReservationEnded, got %v", err)
+	}
+
+	noShow := newConfirmed()
+	grace := 15 * time.Minute
+	if err := noShow.MarkNoShow(start.Add(10*time.Minute), grace); err != ErrNoShowGraceNotElapsed {
+		t.Errorf("Expected ErrNoShowGraceNotElapsed, got %v", err)
+	}
+	if err := noShow.MarkNoShow(start.Add(grace), grace); err != nil {
+		t.Fatalf("MarkNoShow() error = %v", err)
+	}
+	if noShow.Status.IsActive() || noShow.NoShowAt == nil {
+		t.Errorf("Expected inactive no-show reservation, got %s", noShow.Status)
+	}
+}
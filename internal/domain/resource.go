@@ -0,0 +1,238 @@
+package domain
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// ResourceType リソース種別
+type ResourceType string
+
+const (
+	ResourceTypeMeetingRoom ResourceType = "meeting_room"
+	ResourceTypeSeat        ResourceType = "seat"
+	ResourceTypeTable       ResourceType = "table"
+	ResourceTypeDevice      ResourceType = "device"
+)
+
+// IsValid 定義済みのリソース種別かチェック
+func (t ResourceType) IsValid() bool {
+	switch t {
+	case ResourceTypeMeetingRoom, ResourceTypeSeat, ResourceTypeTable, ResourceTypeDevice:
+		return true
+	}
+	return false
+}
+
+// Resource 予約対象リソースエンティティ（集約ルート）
+type Resource struct {
+	ID                    uint                `json:"id" gorm:"primaryKey"`
+	OrganizationID        uint                `json:"organization_id" gorm:"index"`
+	Name                  string              `json:"name" gorm:"not null"`
+	Type                  ResourceType        `json:"type" gorm:"not null"`
+	Capacity              int                 `json:"capacity" gorm:"not null"`
+	Description           string              `json:"description"`
+	TimeZone              string              `json:"time_zone" gorm:"not null;default:'UTC'"`
+	SetupBufferMinutes    int                 `json:"setup_buffer_minutes" gorm:"not null;default:0"`
+	TeardownBufferMinutes int                 `json:"teardown_buffer_minutes" gorm:"not null;default:0"`
+	RequiresApproval      bool                `json:"requires_approval" gorm:"not null;default:false"`
+	Approvers             []*ResourceApprover `json:"approvers" gorm:"foreignKey:ResourceID"`
+	Staff                 []*ResourceStaff    `json:"staff" gorm:"foreignKey:ResourceID"`
+	OpeningHours          Schedule            `json:"opening_hours" gorm:"foreignKey:ResourceID"`
+	CancellationPolicy    CancellationPolicy  `json:"cancellation_policy" gorm:"foreignKey:ResourceID"`
+	CreatedAt             time.Time           `json:"created_at"`
+	UpdatedAt             time.Time           `json:"updated_at"`
+}
+
+// NewResource 新規リソースを作成
+func NewResource(name string, resourceType ResourceType, capacity int, description string) (*Resource, error) {
+	r := &Resource{TimeZone: "UTC"}
+	if err := r.Update(name, resourceType, capacity, description); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Update リソースの属性を更新
+func (r *Resource) Update(name string, resourceType ResourceType, capacity int, description string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return ErrInvalidResource
+	}
+	if !resourceType.IsValid() {
+		return ErrInvalidResourceType
+	}
+	if capacity <= 0 {
+		return ErrInvalidCapacity
+	}
+
+	r.Name = name
+	r.Type = resourceType
+	r.Capacity = capacity
+	r.Description = description
+	return nil
+}
+
+// SetTimeZone IANA タイムゾーン名を設定（空の場合は UTC）
+func (r *Resource) SetTimeZone(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name = "UTC"
+	}
+	if _, err := time.LoadLocation(name); err != nil {
+		return ErrInvalidTimeZone
+	}
+	r.TimeZone = name
+	return nil
+}
+
+// Location リソースのタイムゾーン（未設定・不明な場合は UTC）
+func (r *Resource) Location() *time.Location {
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// SetBuffers 予約の前後に確保する準備・片付け時間（分）を設定
+func (r *Resource) SetBuffers(setupMinutes, teardownMinutes int) error {
+	if setupMinutes < 0 || setupMinutes > 24*60 {
+		return ErrInvalidBuffer
+	}
+	if teardownMinutes < 0 || teardownMinutes > 24*60 {
+		return ErrInvalidBuffer
+	}
+
+	r.SetupBufferMinutes = setupMinutes
+	r.TeardownBufferMinutes = teardownMinutes
+	return nil
+}
+
+// BlockingWindow 時間枠と重なると競合する予約の範囲を返す
+// 既存予約も時間枠の予約自身も、開始前の準備時間と終了後の片付け時間を占有するため、
+// 時間枠を前後に準備時間と片付け時間の合計だけ広げて既存予約の時間帯と比較すればよい
+// 重なり判定に使う StartAt/EndAt のみを広げ、表示用のローカル時刻は変更しない
+func (r *Resource) BlockingWindow(ts *TimeSlot) *TimeSlot {
+	buffers := time.Duration(r.SetupBufferMinutes+r.TeardownBufferMinutes) * time.Minute
+	window := *ts
+	window.StartAt = ts.StartAt.Add(-buffers)
+	window.EndAt = ts.EndAt.Add(buffers)
+	return &window
+}
+
+// PeakBooked 時間枠の中で同時に使われる席数の最大値
+// 時間帯が重なっていても互いに重ならない予約（前半だけ・後半だけの予約など）の席数は合算しない
+// 既存予約も時間枠の予約自身も、前の準備時間と後ろの片付け時間を占有する
+// そのため一方の片付け時間と他方の準備時間が重なる予約も競合として数える。他リソースや有効でない予約は無視する
+func (r *Resource) PeakBooked(ts *TimeSlot, reservations []*Reservation) int {
+	setup := time.Duration(r.SetupBufferMinutes) * time.Minute
+	teardown := time.Duration(r.TeardownBufferMinutes) * time.Minute
+	windowStart := ts.StartAt.Add(-setup)
+	windowEnd := ts.EndAt.Add(teardown)
+
+	type event struct {
+		at    time.Time
+		delta int
+	}
+	var events []event
+	for _, reservation := range reservations {
+		if reservation.ResourceID != r.ID || !reservation.Status.IsActive() || reservation.TimeSlot == nil {
+			continue
+		}
+		start := reservation.TimeSlot.StartAt.Add(-setup)
+		end := reservation.TimeSlot.EndAt.Add(teardown)
+		if !start.Before(windowEnd) || !end.After(windowStart) {
+			continue
+		}
+		events = append(events, event{start, reservation.Quantity}, event{end, -reservation.Quantity})
+	}
+
+	// 同時刻では終了を先に処理し、ちょうど入れ替わる予約を重ねて数えない
+	sort.Slice(events, func(i, j int) bool {
+		if !events[i].at.Equal(events[j].at) {
+			return events[i].at.Before(events[j].at)
+		}
+		return events[i].delta < events[j].delta
+	})
+
+	peak, current := 0, 0
+	for _, e := range events {
+		current += e.delta
+		if current > peak {
+			peak = current
+		}
+	}
+	return peak
+}
+
+// SetSchedule 公開スケジュールを置き換え
+func (r *Resource) SetSchedule(hours []*OpeningHours) error {
+	schedule, err := NewSchedule(hours)
+	if err != nil {
+		return err
+	}
+	for _, h := range schedule {
+		h.ResourceID = r.ID
+	}
+	r.OpeningHours = schedule
+	return nil
+}
+
+// SetCancellationPolicy キャンセルポリシーを置き換え
+func (r *Resource) SetCancellationPolicy(tiers []*CancellationTier) error {
+	policy, err := NewCancellationPolicy(tiers)
+	if err != nil {
+		return err
+	}
+	for _, tier := range policy {
+		tier.ResourceID = r.ID
+	}
+	r.CancellationPolicy = policy
+	return nil
+}
+
+// NewTimeSpan リソースのタイムゾーンでのローカルの開始・終了日時と定員で日付をまたぐ時間枠を作成
+// 公開スケジュールの枠に沿わない時間帯はErrSlotNotInSchedule
+func (r *Resource) NewTimeSpan(startDate time.Time, startTime string, endDate time.Time, endTime string) (*TimeSlot, error) {
+	ts, err := NewLocalTimeSpan(r.Location(), startDate, startTime, endDate, endTime, r.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.OpeningHours.Validate(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// NewTimeSlot リソースのタイムゾーンでのローカル日付・時刻と定員で時間枠を作成
+// 公開スケジュールの枠に沿わない時間帯はErrSlotNotInSchedule
+func (r *Resource) NewTimeSlot(date time.Time, startTime, endTime string) (*TimeSlot, error) {
+	ts, err := NewLocalTimeSlot(r.Location(), date, startTime, endTime, r.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.OpeningHours.Validate(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// TimeSlotAt 絶対時刻からリソースのタイムゾーン・定員で時間枠を作成
+// 公開スケジュールの枠に沿わない時間帯はErrSlotNotInSchedule
+func (r *Resource) TimeSlotAt(startAt, endAt time.Time) (*TimeSlot, error) {
+	ts, err := NewTimeSlotFromInstants(r.Location(), startAt, endAt, r.Capacity)
+	if err != nil {
+		return nil, err
+	}
+	if err := r.OpeningHours.Validate(ts); err != nil {
+		return nil, err
+	}
+	return ts, nil
+}
+
+// SlotsOn リソースのタイムゾーンでの指定日の予約可能な時間枠を生成
+func (r *Resource) SlotsOn(date time.Time) []*TimeSlot {
+	return r.OpeningHours.SlotsOn(r.Location(), date, r.Capacity)
+}
@@ -0,0 +1,191 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewResource(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceName string
+		resourceType ResourceType
+		capacity     int
+		wantErr      error
+	}{
+		{
+			name:         "Valid resource",
+			resourceName: "Room A",
+			resourceType: ResourceTypeMeetingRoom,
+			capacity:     8,
+			wantErr:      nil,
+		},
+		{
+			name:         "Empty name",
+			resourceName: "  ",
+			resourceType: ResourceTypeMeetingRoom,
+			capacity:     8,
+			wantErr:      ErrInvalidResource,
+		},
+		{
+			name:         "Unknown type",
+			resourceName: "Room A",
+			resourceType: ResourceType("spaceship"),
+			capacity:     8,
+			wantErr:      ErrInvalidResourceType,
+		},
+		{
+			name:         "Invalid capacity",
+			resourceName: "Room A",
+			resourceType: ResourceTypeMeetingRoom,
+			capacity:     0,
+			wantErr:      ErrInvalidCapacity,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resource, err := NewResource(tt.resourceName, tt.resourceType, tt.capacity, "")
+			if err != tt.wantErr {
+				t.Errorf("NewResource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if resource == nil && tt.wantErr == nil {
+				t.Error("NewResource() returned nil resource")
+			}
+		})
+	}
+}
+
+func TestResourceNewTimeSlotUsesResourceCapacity(t *testing.T) {
+	resource, err := NewResource("Desk 12", ResourceTypeSeat, 3, "")
+	if err != nil {
+		t.Fatalf("NewResource() error = %v", err)
+	}
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC) // Monday
+	hours, _ := NewOpeningHours(time.Monday, "09:00", "18:00", 60)
+	if err := resource.SetSchedule([]*OpeningHours{hours}); err != nil {
+		t.Fatalf("SetSchedule() error = %v", err)
+	}
+
+	ts, err := resource.NewTimeSlot(date, "09:00", "10:00")
+	if err != nil {
+		t.Fatalf("NewTimeSlot() error = %v", err)
+	}
+
+	if ts.Capacity != resource.Capacity {
+		t.Errorf("Expected capacity %d, got %d", resource.Capacity, ts.Capacity)
+	}
+}
+
+func TestResourceNewTimeSlotRejectsUnpublishedSlots(t *testing.T) {
+	resource, _ := NewResource("Room A", ResourceTypeMeetingRoom, 8, "")
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC) // Monday
+
+	if _, err := resource.NewTimeSlot(date, "09:00", "10:00"); err != ErrSlotNotInSchedule {
+		t.Errorf("Expected ErrSlotNotInSchedule without a schedule, got %v", err)
+	}
+}
+
+func TestResourceBlockingWindow(t *testing.T) {
+	resource, _ := NewResource("Room A", ResourceTypeMeetingRoom, 1, "")
+	if err := resource.SetBuffers(-1, 0); err != ErrInvalidBuffer {
+		t.Errorf("Expected ErrInvalidBuffer, got %v", err)
+	}
+	_ = resource.SetBuffers(15, 10)
+
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	booked, _ := NewTimeSlot(date, "10:00", "11:00", 1)
+
+	tests := []struct {
+		start, end string
+		want       bool
+	}{
+		{"09:00", "09:50", true}, // 予約の準備時間と重なる
+		{"09:00", "09:40", true}, // 片付け時間が予約の準備時間と重なる
+		{"09:00", "09:35", false},
+		{"11:00", "12:00", true}, // 予約の片付け時間と重なる
+		{"11:20", "12:00", true}, // 準備時間が予約の片付け時間と重なる
+		{"11:25", "12:00", false},
+	}
+
+	for _, tt := range tests {
+		ts, _ := NewTimeSlot(date, tt.start, tt.end, 1)
+		if got := resource.BlockingWindow(ts).Overlaps(booked); got != tt.want {
+			t.Errorf("%s-%s conflicts = %v, want %v", tt.start, tt.end, got, tt.want)
+		}
+		if ts.StartTime != tt.start || ts.EndTime != tt.end {
+			t.Errorf("BlockingWindow() must not change the displayed slot, got %s-%s", ts.StartTime, ts.EndTime)
+		}
+	}
+
+	// 片付け時間は日付をまたいで前日の予約とも重なる
+	early, _ := NewTimeSlot(date, "00:00", "00:30", 1)
+	late, _ := NewTimeSlot(date.AddDate(0, 0, -1), "23:30", "24:00", 1)
+	if !resource.BlockingWindow(early).Overlaps(late) {
+		t.Error("Expected buffer to reach into the previous day")
+	}
+}
+
+func TestResourcePeakBooked(t *testing.T) {
+	resource, _ := NewResource("Projector", ResourceTypeDevice, 2, "")
+	resource.ID = 1
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	day := func(offset int, quantity int) *Reservation {
+		ts, _ := NewLocalTimeSpan(time.UTC, monday.AddDate(0, 0, offset), "09:00", monday.AddDate(0, 0, offset), "18:00", resource.Capacity)
+		r, _ := NewReservation(1, resource.ID, ts, quantity)
+		return r
+	}
+	rental, _ := NewLocalTimeSpan(time.UTC, monday, "09:00", monday.AddDate(0, 0, 2), "18:00", resource.Capacity)
+
+	// 月曜と水曜の予約は互いに重ならないため、3日間の貸出と同時に使われるのは1台まで
+	reservations := []*Reservation{day(0, 1), day(2, 1)}
+	if got := resource.PeakBooked(rental, reservations); got != 1 {
+		t.Errorf("PeakBooked() = %d, want 1", got)
+	}
+
+	reservations = append(reservations, day(2, 1))
+	if got := resource.PeakBooked(rental, reservations); got != 2 {
+		t.Errorf("PeakBooked() = %d, want 2", got)
+	}
+
+	// ちょうど入れ替わる予約は重ねて数えない（バッファなし）
+	morning, _ := NewTimeSlot(monday, "09:00", "12:00", resource.Capacity)
+	afternoon, _ := NewTimeSlot(monday, "12:00", "18:00", resource.Capacity)
+	first, _ := NewReservation(1, resource.ID, morning, 2)
+	second, _ := NewReservation(2, resource.ID, afternoon, 2)
+	if got := resource.PeakBooked(day(0, 1).TimeSlot, []*Reservation{first, second}); got != 2 {
+		t.Errorf("PeakBooked() = %d, want 2", got)
+	}
+}
+
+func TestResourcePeakBookedIncludesOwnBuffers(t *testing.T) {
+	resource, _ := NewResource("Room A", ResourceTypeMeetingRoom, 1, "")
+	resource.ID = 1
+	_ = resource.SetBuffers(15, 15)
+
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := NewTimeSlot(date, "10:00", "11:00", resource.Capacity)
+	booking, _ := NewReservation(1, resource.ID, ts, 1)
+
+	// 11:15 開始の準備時間は 10:00-11:00 の予約の片付け時間と重なる
+	tests := []struct {
+		start, end string
+		want       int
+	}{
+		{"11:15", "12:00", 1},
+		{"11:30", "12:00", 0},
+		{"08:45", "09:45", 1},
+		{"08:30", "09:30", 0},
+	}
+	for _, tt := range tests {
+		slot, _ := NewTimeSlot(date, tt.start, tt.end, resource.Capacity)
+		if got := resource.PeakBooked(slot, []*Reservation{booking}); got != tt.want {
+			t.Errorf("PeakBooked(%s-%s) = %d, want %d", tt.start, tt.end, got, tt.want)
+		}
+		if got := resource.BlockingWindow(slot).Overlaps(ts); got != (tt.want > 0) {
+			t.Errorf("BlockingWindow(%s-%s) overlaps = %v, want %v", tt.start, tt.end, got, tt.want > 0)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package domain
+
+import "time"
+
+// Role ユーザーの権限
+type Role string
+
+const (
+	RoleAdmin  Role = "admin"
+	RoleStaff  Role = "staff"
+	RoleMember Role = "member"
+)
+
+// ParseRole 文字列を権限に変換
+func ParseRole(value string) (Role, error) {
+	role := Role(value)
+	switch role {
+	case RoleAdmin, RoleStaff, RoleMember:
+		return role, nil
+	}
+	return "", ErrInvalidRole
+}
+
+// HasStaffPrivileges スタッフ以上の権限かチェック
+func (r Role) HasStaffPrivileges() bool {
+	return r == RoleStaff || r == RoleAdmin
+}
+
+// Principal 認証済みの操作主体
+// OrganizationID はトークン発行時に選択した組織で、リソースと予約の参照はこの組織に限られる
+// TokenID と TokenExpiresAt は認証に使ったアクセストークンのもので、ログアウト時の失効に使う
+type Principal struct {
+	UserID         uint
+	Email          string
+	Role           Role
+	OrganizationID uint
+	TokenID        string
+	TokenExpiresAt time.Time
+}
+
+// IsAdmin 管理者かチェック
+func (p *Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// HasRole いずれかの権限を持つかチェック
+func (p *Principal) HasRole(roles ...Role) bool {
+	for _, role := range roles {
+		if p.Role == role {
+			return true
+		}
+	}
+	return false
+}
+
+// ActAs 操作対象のユーザーを決定
+// userID が 0 なら本人、本人以外を指定できるのは代理操作を行う管理者のみ
+func (p *Principal) ActAs(userID uint) (uint, error) {
+	if userID == 0 || userID == p.UserID {
+		return p.UserID, nil
+	}
+	if p.IsAdmin() {
+		return userID, nil
+	}
+	return 0, ErrUnauthorized
+}
+
+// CanManage リソースのスタッフ業務（チェックイン、予約一覧の閲覧など）を行えるかチェック
+// 管理者はすべてのリソース、スタッフは担当リソースのみ
+func (p *Principal) CanManage(resource *Resource) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	return p.Role == RoleStaff && resource.HasStaff(p.UserID)
+}
+
+// CanView 予約を閲覧できるかチェック
+// 利用者は自分の予約のみ、スタッフは担当リソースの予約も閲覧できる
+func (p *Principal) CanView(reservation *Reservation, resource *Resource) bool {
+	if reservation.UserID == p.UserID {
+		return true
+	}
+	return p.CanManage(resource)
+}
+
+// ResourceStaff リソースを担当するスタッフ
+type ResourceStaff struct {
+	ID         uint `json:"-" gorm:"primaryKey"`
+	ResourceID uint `json:"resource_id" gorm:"not null;uniqueIndex:idx_resource_staff"`
+	UserID     uint `json:"user_id" gorm:"not null;uniqueIndex:idx_resource_staff"`
+}
+
+// SetStaff 担当スタッフを置き換え
+func (r *Resource) SetStaff(userIDs []uint) error {
+	staff := make([]*ResourceStaff, 0, len(userIDs))
+	seen := make(map[uint]bool, len(userIDs))
+	for _, userID := range userIDs {
+		if userID == 0 {
+			return ErrInvalidStaff
+		}
+		if seen[userID] {
+			continue
+		}
+		seen[userID] = true
+		staff = append(staff, &ResourceStaff{ResourceID: r.ID, UserID: userID})
+	}
+
+	r.Staff = staff
+	return nil
+}
+
+// HasStaff 指定ユーザーがリソースの担当スタッフかチェック
+func (r *Resource) HasStaff(userID uint) bool {
+	for _, s := range r.Staff {
+		if s.UserID == userID {
+			return true
+		}
+	}
+	return false
+}
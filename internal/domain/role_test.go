@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseRole(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    Role
+		wantErr error
+	}{
+		{"admin", RoleAdmin, nil},
+		{"staff", RoleStaff, nil},
+		{"member", RoleMember, nil},
+		{"owner", "", ErrInvalidRole},
+		{"", "", ErrInvalidRole},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseRole(tt.value)
+		if got != tt.want || err != tt.wantErr {
+			t.Errorf("ParseRole(%q) = %q, %v, want %q, %v", tt.value, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestMembershipSetRole(t *testing.T) {
+	membership, _ := NewMembership(1, 2)
+	if membership.Role != RoleMember {
+		t.Errorf("New memberships should be members, got %s", membership.Role)
+	}
+
+	if err := membership.SetRole(Role("owner")); err != ErrInvalidRole {
+		t.Errorf("Expected ErrInvalidRole, got %v", err)
+	}
+	if err := membership.SetRole(RoleStaff); err != nil || membership.Role != RoleStaff {
+		t.Errorf("SetRole() = %v, role %s", err, membership.Role)
+	}
+}
+
+func TestPrincipalPermissions(t *testing.T) {
+	resource, _ := NewResource("Room A", ResourceTypeMeetingRoom, 4, "")
+	resource.ID = 1
+	if err := resource.SetStaff([]uint{0}); err != ErrInvalidStaff {
+		t.Errorf("Expected ErrInvalidStaff for user 0, got %v", err)
+	}
+	_ = resource.SetStaff([]uint{2, 2})
+	if len(resource.Staff) != 1 {
+		t.Errorf("Expected duplicate staff to be removed, got %d", len(resource.Staff))
+	}
+
+	ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 4)
+	reservation, _ := NewReservation(5, resource.ID, ts, 1)
+
+	tests := []struct {
+		name       string
+		principal  *Principal
+		wantManage bool
+		wantView   bool
+	}{
+		{"Admin", &Principal{UserID: 1, Role: RoleAdmin}, true, true},
+		{"Assigned staff", &Principal{UserID: 2, Role: RoleStaff}, true, true},
+		{"Other staff", &Principal{UserID: 3, Role: RoleStaff}, false, false},
+		{"Owner", &Principal{UserID: 5, Role: RoleMember}, false, true},
+		{"Other member", &Principal{UserID: 6, Role: RoleMember}, false, false},
+		// 担当に登録されていても member に戻されたユーザーは管理できない
+		{"Demoted staff", &Principal{UserID: 2, Role: RoleMember}, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.principal.CanManage(resource); got != tt.wantManage {
+				t.Errorf("CanManage() = %v, want %v", got, tt.wantManage)
+			}
+			if got := tt.principal.CanView(reservation, resource); got != tt.wantView {
+				t.Errorf("CanView() = %v, want %v", got, tt.wantView)
+			}
+		})
+	}
+}
+
+func TestPrincipalActAs(t *testing.T) {
+	member := &Principal{UserID: 5, Role: RoleMember}
+	admin := &Principal{UserID: 1, Role: RoleAdmin}
+
+	tests := []struct {
+		name      string
+		principal *Principal
+		requested uint
+		want      uint
+		wantErr   error
+	}{
+		{"Defaults to self", member, 0, 5, nil},
+		{"Explicit self", member, 5, 5, nil},
+		{"Member on behalf of another user", member, 6, 0, ErrUnauthorized},
+		{"Staff on behalf of another user", &Principal{UserID: 2, Role: RoleStaff}, 6, 0, ErrUnauthorized},
+		{"Admin on behalf of another user", admin, 6, 6, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.principal.ActAs(tt.requested)
+			if got != tt.want || err != tt.wantErr {
+				t.Errorf("ActAs(%d) = %d, %v, want %d, %v", tt.requested, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
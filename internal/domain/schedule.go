@@ -0,0 +1,185 @@
+package domain
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// OpeningHours 曜日ごとの営業時間エンティティ
+type OpeningHours struct {
+	ID          uint         `json:"id" gorm:"primaryKey"`
+	ResourceID  uint         `json:"resource_id" gorm:"not null;index"`
+	Weekday     time.Weekday `json:"weekday" gorm:"not null"`
+	OpenTime    string       `json:"open_time" gorm:"not null"`
+	CloseTime   string       `json:"close_time" gorm:"not null"`
+	SlotMinutes int          `json:"slot_minutes" gorm:"not null"`
+}
+
+// NewOpeningHours 新規営業時間を作成
+// 営業時間の長さは枠の長さで割り切れる必要がある
+func NewOpeningHours(weekday time.Weekday, openTime, closeTime string, slotMinutes int) (*OpeningHours, error) {
+	if weekday < time.Sunday || weekday > time.Saturday {
+		return nil, ErrInvalidSchedule
+	}
+	if slotMinutes <= 0 {
+		return nil, ErrInvalidSchedule
+	}
+
+	open, err := parseClock(openTime)
+	if err != nil {
+		return nil, ErrInvalidSchedule
+	}
+	closing, err := parseClock(closeTime)
+	if err != nil {
+		return nil, ErrInvalidSchedule
+	}
+
+	if open >= closing || (closing-open)%slotMinutes != 0 {
+		return nil, ErrInvalidSchedule
+	}
+
+	return &OpeningHours{
+		Weekday:     weekday,
+		OpenTime:    openTime,
+		CloseTime:   closeTime,
+		SlotMinutes: slotMinutes,
+	}, nil
+}
+
+// bounds 営業開始・終了を0時からの分で返す
+func (h *OpeningHours) bounds() (int, int) {
+	open, _ := parseClock(h.OpenTime)
+	closing, _ := parseClock(h.CloseTime)
+	return open, closing
+}
+
+// Schedule リソースの週間スケジュール
+type Schedule []*OpeningHours
+
+// NewSchedule 週間スケジュールを作成
+// 同じ曜日で営業時間が重なる場合はエラー
+func NewSchedule(hours []*OpeningHours) (Schedule, error) {
+	schedule := make(Schedule, len(hours))
+	copy(schedule, hours)
+	sort.Slice(schedule, func(i, j int) bool {
+		if schedule[i].Weekday != schedule[j].Weekday {
+			return schedule[i].Weekday < schedule[j].Weekday
+		}
+		return schedule[i].OpenTime < schedule[j].OpenTime
+	})
+
+	for i := 1; i < len(schedule); i++ {
+		prev, cur := schedule[i-1], schedule[i]
+		if prev.Weekday != cur.Weekday {
+			continue
+		}
+		_, prevClose := prev.bounds()
+		curOpen, _ := cur.bounds()
+		if curOpen < prevClose {
+			return nil, ErrInvalidSchedule
+		}
+	}
+
+	return schedule, nil
+}
+
+// SlotsOn loc での指定日の予約可能な時間枠を生成
+// 夏時間の切り替えで存在しないローカル時刻にかかる枠は除く
+func (s Schedule) SlotsOn(loc *time.Location, date time.Time, capacity int) []*TimeSlot {
+	var slots []*TimeSlot
+	for _, hours := range s.on(date.Weekday()) {
+		open, closing := hours.bounds()
+		for start := open; start+hours.SlotMinutes <= closing; start += hours.SlotMinutes {
+			slot, err := NewLocalTimeSlot(loc, date, formatClock(start), formatClock(start+hours.SlotMinutes), capacity)
+			if err != nil {
+				continue
+			}
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// Validate 時間枠が公開スケジュールの枠境界に沿っているかチェック
+// 複数の連続した枠にまたがる予約は許可する
+// 日付をまたぐ時間枠は、開始日の営業時間内の枠の開始から終了日の営業時間内の枠の終了までとし、
+// 途中の営業時間外（夜間や定休日）も含めて押さえる
+func (s Schedule) Validate(ts *TimeSlot) error {
+	start, end, err := ts.clockRange()
+	if err != nil {
+		return ErrInvalidTimeRange
+	}
+
+	if ts.SpansDays() {
+		if s.opensAt(ts.Date.Weekday(), start) && s.closesAt(ts.EndDate.Weekday(), end) {
+			return nil
+		}
+		return ErrSlotNotInSchedule
+	}
+
+	for _, hours := range s.on(ts.Date.Weekday()) {
+		open, closing := hours.bounds()
+		if start < open || end > closing {
+			continue
+		}
+		if (start-open)%hours.SlotMinutes == 0 && (end-open)%hours.SlotMinutes == 0 {
+			return nil
+		}
+	}
+
+	return ErrSlotNotInSchedule
+}
+
+// opensAt 指定曜日の営業時間内で minute から始まる枠があるかチェック
+func (s Schedule) opensAt(weekday time.Weekday, minute int) bool {
+	for _, hours := range s.on(weekday) {
+		open, closing := hours.bounds()
+		if minute >= open && minute < closing && (minute-open)%hours.SlotMinutes == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// closesAt 指定曜日の営業時間内で minute に終わる枠があるかチェック
+func (s Schedule) closesAt(weekday time.Weekday, minute int) bool {
+	for _, hours := range s.on(weekday) {
+		open, closing := hours.bounds()
+		if minute > open && minute <= closing && (minute-open)%hours.SlotMinutes == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+func (s Schedule) on(weekday time.Weekday) []*OpeningHours {
+	var hours []*OpeningHours
+	for _, h := range s {
+		if h.Weekday == weekday {
+			hours = append(hours, h)
+		}
+	}
+	sort.Slice(hours, func(i, j int) bool {
+		return hours[i].OpenTime < hours[j].OpenTime
+	})
+	return hours
+}
+
+// parseClock "15:04"形式の時刻を0時からの分に変換
+// 終業時刻として"24:00"も受け付ける
+func parseClock(value string) (int, error) {
+	if value == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, err
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+// formatClock 0時からの分を"15:04"形式に変換
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
@@ -0,0 +1,218 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewOpeningHours(t *testing.T) {
+	tests := []struct {
+		name        string
+		weekday     time.Weekday
+		openTime    string
+		closeTime   string
+		slotMinutes int
+		wantErr     bool
+	}{
+		{
+			name:        "Valid opening hours",
+			weekday:     time.Monday,
+			openTime:    "09:00",
+			closeTime:   "18:00",
+			slotMinutes: 30,
+			wantErr:     false,
+		},
+		{
+			name:        "Open until midnight",
+			weekday:     time.Friday,
+			openTime:    "18:00",
+			closeTime:   "24:00",
+			slotMinutes: 60,
+			wantErr:     false,
+		},
+		{
+			name:        "Invalid weekday",
+			weekday:     time.Weekday(7),
+			openTime:    "09:00",
+			closeTime:   "18:00",
+			slotMinutes: 30,
+			wantErr:     true,
+		},
+		{
+			name:        "Close before open",
+			weekday:     time.Monday,
+			openTime:    "18:00",
+			closeTime:   "09:00",
+			slotMinutes: 30,
+			wantErr:     true,
+		},
+		{
+			name:        "Slot length does not divide opening hours",
+			weekday:     time.Monday,
+			openTime:    "09:00",
+			closeTime:   "10:00",
+			slotMinutes: 45,
+			wantErr:     true,
+		},
+		{
+			name:        "Zero slot length",
+			weekday:     time.Monday,
+			openTime:    "09:00",
+			closeTime:   "10:00",
+			slotMinutes: 0,
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hours, err := NewOpeningHours(tt.weekday, tt.openTime, tt.closeTime, tt.slotMinutes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewOpeningHours() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if hours == nil && !tt.wantErr {
+				t.Error("NewOpeningHours() returned nil opening hours")
+			}
+		})
+	}
+}
+
+func TestNewScheduleRejectsOverlappingHours(t *testing.T) {
+	morning, _ := NewOpeningHours(time.Monday, "09:00", "12:00", 30)
+	overlapping, _ := NewOpeningHours(time.Monday, "11:00", "14:00", 30)
+
+	if _, err := NewSchedule([]*OpeningHours{morning, overlapping}); err != ErrInvalidSchedule {
+		t.Errorf("Expected ErrInvalidSchedule, got %v", err)
+	}
+
+	afternoon, _ := NewOpeningHours(time.Monday, "13:00", "17:00", 60)
+	if _, err := NewSchedule([]*OpeningHours{afternoon, morning}); err != nil {
+		t.Errorf("NewSchedule() error = %v", err)
+	}
+}
+
+func TestScheduleSlotsOn(t *testing.T) {
+	hours, _ := NewOpeningHours(time.Monday, "09:00", "11:00", 30)
+	schedule, _ := NewSchedule([]*OpeningHours{hours})
+
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	slots := schedule.SlotsOn(time.UTC, monday, 4)
+
+	expected := [][2]string{{"09:00", "09:30"}, {"09:30", "10:00"}, {"10:00", "10:30"}, {"10:30", "11:00"}}
+	if len(slots) != len(expected) {
+		t.Fatalf("Expected %d slots, got %d", len(expected), len(slots))
+	}
+	for i, slot := range slots {
+		if slot.StartTime != expected[i][0] || slot.EndTime != expected[i][1] {
+			t.Errorf("Slot %d = %s-%s, want %s-%s", i, slot.StartTime, slot.EndTime, expected[i][0], expected[i][1])
+		}
+		if slot.Capacity != 4 {
+			t.Errorf("Slot %d capacity = %d, want 4", i, slot.Capacity)
+		}
+	}
+
+	if slots := schedule.SlotsOn(time.UTC, monday.AddDate(0, 0, 1), 4); len(slots) != 0 {
+		t.Errorf("Expected no slots on Tuesday, got %d", len(slots))
+	}
+}
+
+func TestScheduleValidate(t *testing.T) {
+	hours, _ := NewOpeningHours(time.Monday, "09:00", "18:00", 30)
+	schedule, _ := NewSchedule([]*OpeningHours{hours})
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		date      time.Time
+		startTime string
+		endTime   string
+		wantErr   error
+	}{
+		{
+			name:      "Single slot",
+			date:      monday,
+			startTime: "09:00",
+			endTime:   "09:30",
+			wantErr:   nil,
+		},
+		{
+			name:      "Consecutive slots",
+			date:      monday,
+			startTime: "10:00",
+			endTime:   "11:30",
+			wantErr:   nil,
+		},
+		{
+			name:      "Not aligned to slot boundaries",
+			date:      monday,
+			startTime: "03:17",
+			endTime:   "03:24",
+			wantErr:   ErrSlotNotInSchedule,
+		},
+		{
+			name:      "Outside opening hours",
+			date:      monday,
+			startTime: "17:30",
+			endTime:   "18:30",
+			wantErr:   ErrSlotNotInSchedule,
+		},
+		{
+			name:      "Closed weekday",
+			date:      monday.AddDate(0, 0, 1),
+			startTime: "09:00",
+			endTime:   "09:30",
+			wantErr:   ErrSlotNotInSchedule,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := NewTimeSlot(tt.date, tt.startTime, tt.endTime, 1)
+			if err != nil {
+				t.Fatalf("NewTimeSlot() error = %v", err)
+			}
+			if err := schedule.Validate(ts); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleValidateSpans(t *testing.T) {
+	var hours []*OpeningHours
+	for _, weekday := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday} {
+		day, _ := NewOpeningHours(weekday, "09:00", "18:00", 60)
+		night, _ := NewOpeningHours(weekday, "22:00", "24:00", 60)
+		early, _ := NewOpeningHours(weekday, "00:00", "02:00", 60)
+		hours = append(hours, day, night, early)
+	}
+	schedule, _ := NewSchedule(hours)
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		endDate time.Time
+		start   string
+		end     string
+		wantErr error
+	}{
+		{"Night shift", monday.AddDate(0, 0, 1), "22:00", "02:00", nil},
+		{"Three day rental", monday.AddDate(0, 0, 2), "09:00", "18:00", nil},
+		{"Ends outside opening hours", monday.AddDate(0, 0, 1), "22:00", "03:00", ErrSlotNotInSchedule},
+		{"Starts outside opening hours", monday.AddDate(0, 0, 2), "08:00", "18:00", ErrSlotNotInSchedule},
+		{"Ends on a closed day", monday.AddDate(0, 0, 3), "09:00", "18:00", ErrSlotNotInSchedule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts, err := NewLocalTimeSpan(time.UTC, monday, tt.start, tt.endDate, tt.end, 1)
+			if err != nil {
+				t.Fatalf("NewLocalTimeSpan() error = %v", err)
+			}
+			if err := schedule.Validate(ts); err != tt.wantErr {
+				t.Errorf("Validate() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
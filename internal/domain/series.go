@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"time"
+)
+
+// SeriesCancelScope 繰り返し予約のキャンセル範囲
+type SeriesCancelScope string
+
+const (
+	// CancelScopeOccurrence 指定した1回のみ
+	CancelScopeOccurrence SeriesCancelScope = "occurrence"
+	// CancelScopeFollowing 指定した回とそれ以降
+	CancelScopeFollowing SeriesCancelScope = "following"
+	// CancelScopeSeries シリーズ全体
+	CancelScopeSeries SeriesCancelScope = "series"
+)
+
+// ReservationSeries 繰り返し予約エンティティ
+// 各回は SeriesID で紐づく個別の Reservation として保存する
+type ReservationSeries struct {
+	ID             uint      `json:"id" gorm:"primaryKey"`
+	OrganizationID uint      `json:"organization_id" gorm:"index"`
+	UserID         uint      `json:"user_id" gorm:"not null;index"`
+	ResourceID     uint      `json:"resource_id" gorm:"not null;index"`
+	RRule          string    `json:"rrule" gorm:"not null"`
+	StartDate      time.Time `json:"start_date" gorm:"not null"`
+	StartTime      string    `json:"start_time" gorm:"not null"`
+	EndTime        string    `json:"end_time" gorm:"not null"`
+	Quantity       int       `json:"quantity" gorm:"not null;default:1"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// NewReservationSeries 新規繰り返し予約を作成し、各回の日付を返す
+func NewReservationSeries(userID, resourceID uint, rrule string, startDate time.Time, startTime, endTime string, quantity int) (*ReservationSeries, []time.Time, error) {
+	if userID == 0 {
+		return nil, nil, ErrInvalidUser
+	}
+	if resourceID == 0 {
+		return nil, nil, ErrInvalidResource
+	}
+	if quantity <= 0 {
+		return nil, nil, ErrInvalidQuantity
+	}
+
+	rule, err := ParseRecurrenceRule(rrule)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dates, err := rule.Occurrences(startDate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &ReservationSeries{
+		UserID:     userID,
+		ResourceID: resourceID,
+		RRule:      rrule,
+		StartDate:  startDate,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		Quantity:   quantity,
+	}, dates, nil
+}
+
+// OccurrencesToCancel キャンセル範囲に含まれ、キャンセルポリシー上まだキャンセルできる回を返す
+// target は CancelScopeSeries の場合のみ省略可能
+func (s *ReservationSeries) OccurrencesToCancel(occurrences []*Reservation, target *Reservation, scope SeriesCancelScope, now time.Time, policy CancellationPolicy) ([]*Reservation, error) {
+	switch scope {
+	case CancelScopeOccurrence, CancelScopeFollowing:
+		if target == nil || target.SeriesID == nil || *target.SeriesID != s.ID {
+			return nil, ErrReservationNotInSeries
+		}
+	case CancelScopeSeries:
+	default:
+		return nil, ErrInvalidCancelScope
+	}
+
+	if scope == CancelScopeOccurrence {
+		return []*Reservation{target}, nil
+	}
+
+	var selected []*Reservation
+	for _, occurrence := range occurrences {
+		if !occurrence.CanCancel(now, policy) {
+			continue
+		}
+		if scope == CancelScopeFollowing && occurrence.TimeSlot.StartAt.Before(target.TimeSlot.StartAt) {
+			continue
+		}
+		selected = append(selected, occurrence)
+	}
+	return selected, nil
+}
@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReservationSeriesOccurrencesToCancel(t *testing.T) {
+	start := time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)
+	series, dates, err := NewReservationSeries(1, 1, "FREQ=WEEKLY;BYDAY=TU;COUNT=4", start, "10:00", "11:00", 1)
+	if err != nil {
+		t.Fatalf("NewReservationSeries() error = %v", err)
+	}
+	series.ID = 7
+
+	var occurrences []*Reservation
+	for _, date := range dates {
+		ts, _ := NewTimeSlot(date, series.StartTime, series.EndTime, 5)
+		reservation, _ := NewReservation(series.UserID, series.ResourceID, ts, series.Quantity)
+		reservation.SeriesID = &series.ID
+		occurrences = append(occurrences, reservation)
+	}
+	occurrences[3].Status = StatusCancelled
+	now := start.AddDate(0, 0, -1)
+
+	tests := []struct {
+		name    string
+		target  *Reservation
+		scope   SeriesCancelScope
+		want    int
+		wantErr error
+	}{
+		{name: "Single occurrence", target: occurrences[1], scope: CancelScopeOccurrence, want: 1},
+		{name: "This and following", target: occurrences[1], scope: CancelScopeFollowing, want: 2},
+		{name: "Whole series", target: nil, scope: CancelScopeSeries, want: 3},
+		{name: "Missing target", target: nil, scope: CancelScopeFollowing, wantErr: ErrReservationNotInSeries},
+		{name: "Unknown scope", target: occurrences[0], scope: SeriesCancelScope("all"), wantErr: ErrInvalidCancelScope},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			selected, err := series.OccurrencesToCancel(occurrences, tt.target, tt.scope, now, nil)
+			if err != tt.wantErr {
+				t.Fatalf("OccurrencesToCancel() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if len(selected) != tt.want {
+				t.Errorf("OccurrencesToCancel() selected %d occurrences, want %d", len(selected), tt.want)
+			}
+		})
+	}
+}
+
+func TestReservationSeriesRejectsForeignOccurrence(t *testing.T) {
+	start := time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)
+	series, _, _ := NewReservationSeries(1, 1, "FREQ=DAILY;COUNT=2", start, "10:00", "11:00", 1)
+	series.ID = 7
+
+	ts, _ := NewTimeSlot(start, "10:00", "11:00", 5)
+	standalone, _ := NewReservation(1, 1, ts, 1)
+
+	if _, err := series.OccurrencesToCancel(nil, standalone, CancelScopeOccurrence, start, nil); err != ErrReservationNotInSeries {
+		t.Errorf("Expected ErrReservationNotInSeries, got %v", err)
+	}
+}
+
+func TestReservationSeriesSkipsStartedOccurrences(t *testing.T) {
+	start := time.Date(2026, 1, 6, 0, 0, 0, 0, time.UTC)
+	series, dates, _ := NewReservationSeries(1, 1, "FREQ=DAILY;COUNT=3", start, "10:00", "11:00", 1)
+	series.ID = 7
+
+	var occurrences []*Reservation
+	for _, date := range dates {
+		ts, _ := NewTimeSlot(date, series.StartTime, series.EndTime, 5)
+		reservation, _ := NewReservation(series.UserID, series.ResourceID, ts, series.Quantity)
+		reservation.SeriesID = &series.ID
+		occurrences = append(occurrences, reservation)
+	}
+
+	// 2回目の開始後は、残りの1回だけがキャンセル対象になる
+	now := time.Date(2026, 1, 7, 10, 30, 0, 0, time.UTC)
+	selected, err := series.OccurrencesToCancel(occurrences, nil, CancelScopeSeries, now, nil)
+	if err != nil {
+		t.Fatalf("OccurrencesToCancel() error = %v", err)
+	}
+	if len(selected) != 1 || selected[0] != occurrences[2] {
+		t.Errorf("Expected only the last occurrence, got %d occurrences", len(selected))
+	}
+}
@@ -1,46 +1,250 @@
 package domain
 
 import (
+	"encoding/json"
 	"time"
 )
 
+// MaxTimeSlotDays 1つの時間枠がまたげる最大日数
+const MaxTimeSlotDays = 31
+
 // TimeSlot 時間枠値オブジェクト
+// StartAt/EndAt が絶対時刻で、重なり判定や期限の判定に使う
+// Date/StartTime と EndDate/EndTime は TimeZone でのローカルの開始・終了日時で、スケジュールとの照合と表示に使う
+// （日付はローカルの暦日を UTC の0時で表す。0時ちょうどに終わる場合は前日の "24:00" とする）
 type TimeSlot struct {
 	Date      time.Time `json:"date"`
+	EndDate   time.Time `json:"end_date"`
 	StartTime string    `json:"start_time"`
 	EndTime   string    `json:"end_time"`
+	StartAt   time.Time `json:"start_at" gorm:"type:timestamptz;index"`
+	EndAt     time.Time `json:"end_at" gorm:"type:timestamptz;index"`
+	TimeZone  string    `json:"time_zone" gorm:"not null;default:'UTC'"`
 	Capacity  int       `json:"capacity"`
 }
 
-// NewTimeSlot 新規時間枠を作成
+// NewTimeSlot UTC の時間枠を作成
 func NewTimeSlot(date time.Time, startTime, endTime string, capacity int) (*TimeSlot, error) {
+	return NewLocalTimeSlot(time.UTC, date, startTime, endTime, capacity)
+}
+
+// NewLocalTimeSlot loc のローカル日付・時刻から時間枠を作成
+// 終了時刻が開始時刻より前の場合は翌日の時刻とみなす（22:00〜02:00 など）
+// 夏時間の切り替えで存在しないローカル時刻は ErrInvalidTimeRange
+func NewLocalTimeSlot(loc *time.Location, date time.Time, startTime, endTime string, capacity int) (*TimeSlot, error) {
+	start, err := parseClock(startTime)
+	if err != nil {
+		return nil, ErrInvalidTimeRange
+	}
+	end, err := parseClock(endTime)
+	if err != nil {
+		return nil, ErrInvalidTimeRange
+	}
+
+	endDate := date
+	if end < start {
+		endDate = date.AddDate(0, 0, 1)
+	}
+	return NewLocalTimeSpan(loc, date, startTime, endDate, endTime, capacity)
+}
+
+// NewLocalTimeSpan loc のローカルの開始日時から終了日時までの時間枠を作成
+// 日付をまたぐ場合も MaxTimeSlotDays 日までは1つの時間枠として扱う
+func NewLocalTimeSpan(loc *time.Location, startDate time.Time, startTime string, endDate time.Time, endTime string, capacity int) (*TimeSlot, error) {
 	if capacity <= 0 {
 		return nil, ErrInvalidCapacity
 	}
 
-	// 時間文字列をパースして比較
-	start, err := time.Parse("15:04", startTime)
-	if err != nil {
+	start, err := parseClock(startTime)
+	if err != nil || start == 24*60 {
 		return nil, ErrInvalidTimeRange
 	}
-	end, err := time.Parse("15:04", endTime)
+	end, err := parseClock(endTime)
 	if err != nil {
 		return nil, ErrInvalidTimeRange
 	}
 
-	if start.After(end) || start.Equal(end) {
+	startDay := localDate(startDate)
+	endDay := localDate(endDate)
+	startAt, ok := wallClock(loc, startDay, start)
+	if !ok {
+		return nil, ErrInvalidTimeRange
+	}
+	endAt, ok := wallClock(loc, endDay, end)
+	if !ok {
+		return nil, ErrInvalidTimeRange
+	}
+
+	// 翌日0時ちょうどの終了は前日の "24:00" にそろえる
+	if end == 0 && endDay.After(startDay) {
+		endDay = endDay.AddDate(0, 0, -1)
+		end = 24 * 60
+	}
+
+	return newSpan(loc, startDay, start, endDay, end, startAt, endAt, capacity)
+}
+
+// NewTimeSlotFromInstants 絶対時刻から loc のローカル日付・時刻を持つ時間枠を作成
+// 分単位でない時刻は ErrInvalidTimeRange
+func NewTimeSlotFromInstants(loc *time.Location, startAt, endAt time.Time, capacity int) (*TimeSlot, error) {
+	if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	if startAt.Truncate(time.Minute) != startAt || endAt.Truncate(time.Minute) != endAt {
+		return nil, ErrInvalidTimeRange
+	}
+
+	localStart := startAt.In(loc)
+	localEnd := endAt.In(loc)
+	startDay := localDate(localStart)
+	endDay := localDate(localEnd)
+
+	end := localEnd.Hour()*60 + localEnd.Minute()
+	if end == 0 && endDay.After(startDay) {
+		endDay = endDay.AddDate(0, 0, -1)
+		end = 24 * 60
+	}
+
+	return newSpan(loc, startDay, localStart.Hour()*60+localStart.Minute(), endDay, end, localStart, localEnd, capacity)
+}
+
+// newSpan 開始・終了の絶対時刻を検証して時間枠を組み立てる
+func newSpan(loc *time.Location, startDay time.Time, start int, endDay time.Time, end int, startAt, endAt time.Time, capacity int) (*TimeSlot, error) {
+	if !startAt.Before(endAt) {
+		return nil, ErrInvalidTimeRange
+	}
+	if endDay.Sub(startDay) >= MaxTimeSlotDays*24*time.Hour {
 		return nil, ErrInvalidTimeRange
 	}
 
 	return &TimeSlot{
-		Date:      date,
-		StartTime: startTime,
-		EndTime:   endTime,
+		Date:      startDay,
+		EndDate:   endDay,
+		StartTime: formatClock(start),
+		EndTime:   formatClock(end),
+		StartAt:   startAt,
+		EndAt:     endAt,
+		TimeZone:  loc.String(),
 		Capacity:  capacity,
 	}, nil
 }
 
-// IsAvailable 利用可能かチェック
-func (ts *TimeSlot) IsAvailable(reservedCount int) bool {
-	return ts.Capacity > reservedCount
+// IsAvailable 予約済み席数に対して要求席数分の空きがあるかチェック
+func (ts *TimeSlot) IsAvailable(reservedCount, requested int) bool {
+	if requested <= 0 {
+		return false
+	}
+	return ts.Remaining(reservedCount) >= requested
+}
+
+// Remaining 残り席数
+func (ts *TimeSlot) Remaining(reservedCount int) int {
+	if reservedCount >= ts.Capacity {
+		return 0
+	}
+	return ts.Capacity - reservedCount
+}
+
+// Location 時間枠のタイムゾーン（不明な場合は UTC）
+func (ts *TimeSlot) Location() *time.Location {
+	loc, err := time.LoadLocation(ts.TimeZone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// SpansDays ローカルの日付をまたぐ時間枠かチェック
+func (ts *TimeSlot) SpansDays() bool {
+	return ts.EndDate.After(ts.Date)
+}
+
+// Dates 時間枠がかかるローカルの日付を開始日から順に返す
+func (ts *TimeSlot) Dates() []time.Time {
+	dates := []time.Time{ts.Date}
+	for date := ts.Date.AddDate(0, 0, 1); !date.After(ts.EndDate); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+// clockRange ローカルの開始・終了時刻を0時からの分で返す
+// 日付をまたぐ場合、終了時刻は EndDate の0時からの分
+func (ts *TimeSlot) clockRange() (int, int, error) {
+	start, err := parseClock(ts.StartTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	end, err := parseClock(ts.EndTime)
+	if err != nil {
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// Overlaps 絶対時刻で時間帯が重なるかチェック
+func (ts *TimeSlot) Overlaps(other *TimeSlot) bool {
+	if other == nil {
+		return false
+	}
+	return ts.StartAt.Before(other.EndAt) && other.StartAt.Before(ts.EndAt)
+}
+
+// MarshalJSON 日時を時間枠のタイムゾーンのオフセット付きで出力する
+// DBから読み込んだ時刻は UTC になっているため、ここでローカル時刻に戻す
+func (ts TimeSlot) MarshalJSON() ([]byte, error) {
+	loc := ts.Location()
+	return json.Marshal(struct {
+		Date      string    `json:"date"`
+		EndDate   string    `json:"end_date"`
+		StartTime string    `json:"start_time"`
+		EndTime   string    `json:"end_time"`
+		StartAt   time.Time `json:"start_at"`
+		EndAt     time.Time `json:"end_at"`
+		TimeZone  string    `json:"time_zone"`
+		Capacity  int       `json:"capacity"`
+	}{
+		Date:      ts.Date.Format("2006-01-02"),
+		EndDate:   ts.endDate().Format("2006-01-02"),
+		StartTime: ts.StartTime,
+		EndTime:   ts.EndTime,
+		StartAt:   ts.StartAt.In(loc),
+		EndAt:     ts.EndAt.In(loc),
+		TimeZone:  ts.TimeZone,
+		Capacity:  ts.Capacity,
+	})
+}
+
+// endDate 終了日（EndDate 列の追加前に保存された時間枠は開始日と同じ）
+func (ts *TimeSlot) endDate() time.Time {
+	if ts.EndDate.IsZero() {
+		return ts.Date
+	}
+	return ts.EndDate
+}
+
+// localDate 暦日を UTC の0時で表す
+func localDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// wallClock loc での day の0時から minutes 分後のローカル時刻を絶対時刻に変換
+// 夏時間の切り替えで存在しない時刻の場合は false
+func wallClock(loc *time.Location, day time.Time, minutes int) (time.Time, bool) {
+	if minutes == 24*60 {
+		next := day.AddDate(0, 0, 1)
+		return wallClock(loc, next, 0)
+	}
+
+	hour, minute := minutes/60, minutes%60
+	t := time.Date(day.Year(), day.Month(), day.Day(), hour, minute, 0, 0, loc)
+	if t.Hour() != hour || t.Minute() != minute || t.Day() != day.Day() {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// sameDate 日付部分が等しいかチェック
+func sameDate(a, b time.Time) bool {
+	return a.UTC().Format("2006-01-02") == b.UTC().Format("2006-01-02")
 }
@@ -33,10 +33,18 @@ func TestNewTimeSlot(t *testing.T) {
 			wantErr:   true,
 		},
 		{
-			name:      "Start after end",
+			name:      "End before start spans midnight",
+			date:      date,
+			startTime: "22:00",
+			endTime:   "02:00",
+			capacity:  10,
+			wantErr:   false,
+		},
+		{
+			name:      "Same start and end",
 			date:      date,
 			startTime: "10:00",
-			endTime:   "09:00",
+			endTime:   "10:00",
 			capacity:  10,
 			wantErr:   true,
 		},
@@ -55,3 +63,159 @@ func TestNewTimeSlot(t *testing.T) {
 		})
 	}
 }
+
+func TestTimeSlotIsAvailable(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Now(), "09:00", "10:00", 4)
+
+	tests := []struct {
+		name      string
+		reserved  int
+		requested int
+		want      bool
+	}{
+		{name: "Empty slot", reserved: 0, requested: 4, want: true},
+		{name: "Exactly remaining seats", reserved: 1, requested: 3, want: true},
+		{name: "More than remaining seats", reserved: 2, requested: 3, want: false},
+		{name: "Full slot", reserved: 4, requested: 1, want: false},
+		{name: "Zero seats requested", reserved: 0, requested: 0, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ts.IsAvailable(tt.reserved, tt.requested); got != tt.want {
+				t.Errorf("IsAvailable(%d, %d) = %v, want %v", tt.reserved, tt.requested, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTimeSlotStartAtEndAt(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:30", "24:00", 1)
+
+	if want := time.Date(2026, 1, 12, 9, 30, 0, 0, time.UTC); !ts.StartAt.Equal(want) {
+		t.Errorf("StartAt = %v, want %v", ts.StartAt, want)
+	}
+	if want := time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC); !ts.EndAt.Equal(want) {
+		t.Errorf("EndAt = %v, want %v", ts.EndAt, want)
+	}
+}
+
+func TestNewLocalTimeSlot(t *testing.T) {
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+
+	ts, err := NewLocalTimeSlot(tokyo, time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", 1)
+	if err != nil {
+		t.Fatalf("NewLocalTimeSlot() error = %v", err)
+	}
+	if want := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC); !ts.StartAt.Equal(want) {
+		t.Errorf("StartAt = %v, want %v", ts.StartAt, want)
+	}
+	if ts.TimeZone != "Asia/Tokyo" {
+		t.Errorf("TimeZone = %s, want Asia/Tokyo", ts.TimeZone)
+	}
+}
+
+func TestNewLocalTimeSlotDSTGap(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	// 2026-03-08 は 02:00 から 03:00 に進むため 02:30 は存在しない
+	springForward := time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)
+
+	if _, err := NewLocalTimeSlot(newYork, springForward, "02:30", "03:30", 1); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange, got %v", err)
+	}
+
+	ts, err := NewLocalTimeSlot(newYork, springForward, "01:00", "04:00", 1)
+	if err != nil {
+		t.Fatalf("NewLocalTimeSlot() error = %v", err)
+	}
+	if got := ts.EndAt.Sub(ts.StartAt); got != 2*time.Hour {
+		t.Errorf("Duration = %v, want 2h", got)
+	}
+}
+
+func TestNewTimeSlotFromInstants(t *testing.T) {
+	tokyo, _ := time.LoadLocation("Asia/Tokyo")
+
+	startAt := time.Date(2026, 1, 12, 1, 0, 0, 0, time.UTC)
+	ts, err := NewTimeSlotFromInstants(tokyo, startAt, startAt.Add(time.Hour), 1)
+	if err != nil {
+		t.Fatalf("NewTimeSlotFromInstants() error = %v", err)
+	}
+	if ts.StartTime != "10:00" || ts.EndTime != "11:00" {
+		t.Errorf("Local time = %s-%s, want 10:00-11:00", ts.StartTime, ts.EndTime)
+	}
+	if want := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC); !ts.Date.Equal(want) {
+		t.Errorf("Date = %v, want %v", ts.Date, want)
+	}
+
+	// ローカルの翌日0時ちょうどに終わる枠は 24:00
+	lateStart := time.Date(2026, 1, 12, 14, 0, 0, 0, time.UTC)
+	ts, err = NewTimeSlotFromInstants(tokyo, lateStart, lateStart.Add(time.Hour), 1)
+	if err != nil {
+		t.Fatalf("NewTimeSlotFromInstants() error = %v", err)
+	}
+	if ts.EndTime != "24:00" {
+		t.Errorf("EndTime = %s, want 24:00", ts.EndTime)
+	}
+
+	if _, err := NewTimeSlotFromInstants(tokyo, startAt, startAt, 1); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange for an empty slot, got %v", err)
+	}
+	if _, err := NewTimeSlotFromInstants(tokyo, startAt.Add(30*time.Second), startAt.Add(time.Hour), 1); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange for sub-minute instants, got %v", err)
+	}
+}
+
+func TestNewTimeSlotOvernight(t *testing.T) {
+	ts, err := NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "22:00", "02:00", 1)
+	if err != nil {
+		t.Fatalf("NewTimeSlot() error = %v", err)
+	}
+
+	if want := time.Date(2026, 1, 13, 0, 0, 0, 0, time.UTC); !ts.EndDate.Equal(want) {
+		t.Errorf("EndDate = %v, want %v", ts.EndDate, want)
+	}
+	if want := time.Date(2026, 1, 13, 2, 0, 0, 0, time.UTC); !ts.EndAt.Equal(want) {
+		t.Errorf("EndAt = %v, want %v", ts.EndAt, want)
+	}
+	if !ts.SpansDays() {
+		t.Error("Expected overnight slot to span days")
+	}
+	if got := len(ts.Dates()); got != 2 {
+		t.Errorf("len(Dates()) = %d, want 2", got)
+	}
+}
+
+func TestNewLocalTimeSpan(t *testing.T) {
+	start := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	ts, err := NewLocalTimeSpan(time.UTC, start, "09:00", start.AddDate(0, 0, 2), "18:00", 1)
+	if err != nil {
+		t.Fatalf("NewLocalTimeSpan() error = %v", err)
+	}
+	if got := ts.EndAt.Sub(ts.StartAt); got != 57*time.Hour {
+		t.Errorf("Duration = %v, want 57h", got)
+	}
+
+	// 翌日0時ちょうどの終了は前日の 24:00
+	ts, err = NewLocalTimeSpan(time.UTC, start, "09:00", start.AddDate(0, 0, 3), "00:00", 1)
+	if err != nil {
+		t.Fatalf("NewLocalTimeSpan() error = %v", err)
+	}
+	if ts.EndTime != "24:00" || !ts.EndDate.Equal(start.AddDate(0, 0, 2)) {
+		t.Errorf("End = %s %s, want 24:00 on the third day", ts.EndDate.Format("2006-01-02"), ts.EndTime)
+	}
+
+	if _, err := NewLocalTimeSpan(time.UTC, start, "09:00", start.AddDate(0, 0, -1), "18:00", 1); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange for end before start, got %v", err)
+	}
+	if _, err := NewLocalTimeSpan(time.UTC, start, "09:00", start.AddDate(0, 0, MaxTimeSlotDays), "09:00", 1); err != ErrInvalidTimeRange {
+		t.Errorf("Expected ErrInvalidTimeRange for a span over %d days, got %v", MaxTimeSlotDays, err)
+	}
+}
@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+)
+
+// RefreshToken アクセストークンを再発行するためのリフレッシュトークン
+// 平文はクライアントにだけ返し、サーバーには SHA-256 ハッシュを保存する
+// 使用するたびに同じ FamilyID の新しいトークンへ入れ替え（ローテーション）、使用済みのトークンは再利用できない
+type RefreshToken struct {
+	ID             uint       `json:"-" gorm:"primaryKey"`
+	UserID         uint       `json:"-" gorm:"not null;index"`
+	OrganizationID uint       `json:"-" gorm:"not null"`
+	FamilyID       string     `json:"-" gorm:"not null;index"`
+	TokenHash      string     `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt      time.Time  `json:"-" gorm:"not null;index"`
+	UsedAt         *time.Time `json:"-"`
+	RevokedAt      *time.Time `json:"-"`
+	CreatedAt      time.Time  `json:"-"`
+
+	// 同時に発行したアクセストークン（パスワード再設定などでセッションを終了する際に失効させる）
+	AccessTokenID        string    `json:"-"`
+	AccessTokenExpiresAt time.Time `json:"-" gorm:"index"`
+}
+
+// NewRefreshToken リフレッシュトークンを作成し、保存用のエンティティと平文を返す
+// familyID が空の場合はログインによる新しいファミリーとして作成する
+func NewRefreshToken(userID, organizationID uint, familyID string, now time.Time, ttl time.Duration) (*RefreshToken, string, error) {
+	if userID == 0 || organizationID == 0 || ttl <= 0 {
+		return nil, "", ErrInvalidRefreshToken
+	}
+
+	plaintext, err := randomToken(32)
+	if err != nil {
+		return nil, "", err
+	}
+	if familyID == "" {
+		familyID, err = randomToken(16)
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return &RefreshToken{
+		UserID:         userID,
+		OrganizationID: organizationID,
+		FamilyID:       familyID,
+		TokenHash:      HashToken(plaintext),
+		ExpiresAt:      now.Add(ttl),
+	}, plaintext, nil
+}
+
+// HashToken 保存・検索に使うトークンのハッシュ（リフレッシュトークン・パスワード再設定トークン）
+func HashToken(plaintext string) string {
+	sum := sha256.Sum256([]byte(plaintext))
+	return hex.EncodeToString(sum[:])
+}
+
+// Use リフレッシュトークンを使用済みにする
+// 使用済みのトークンが再び使われた場合は ErrRefreshTokenReused（盗用の可能性があるためファミリーごと失効させる）
+func (t *RefreshToken) Use(now time.Time) error {
+	if t.RevokedAt != nil {
+		return ErrInvalidRefreshToken
+	}
+	if t.UsedAt != nil {
+		return ErrRefreshTokenReused
+	}
+	if !now.Before(t.ExpiresAt) {
+		return ErrInvalidRefreshToken
+	}
+
+	t.UsedAt = &now
+	return nil
+}
+
+// RevokedToken 有効期限前に失効させたアクセストークン（失効リスト）
+// 有効期限を過ぎたアクセストークンは検証で拒否されるため、期限後は削除してよい
+type RevokedToken struct {
+	ID        uint      `json:"-" gorm:"primaryKey"`
+	TokenID   string    `json:"-" gorm:"not null;uniqueIndex"`
+	ExpiresAt time.Time `json:"-" gorm:"not null;index"`
+	CreatedAt time.Time `json:"-"`
+}
+
+// randomToken 暗号論的乱数から URL で安全な文字列を生成
+func randomToken(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
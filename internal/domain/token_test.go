@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	token, plaintext, err := NewRefreshToken(1, 2, "", now, time.Hour)
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if plaintext == "" || token.TokenHash == plaintext {
+		t.Error("Expected only the hash of the plaintext token to be stored")
+	}
+	if token.TokenHash != HashToken(plaintext) {
+		t.Error("TokenHash does not match HashToken(plaintext)")
+	}
+	if token.FamilyID == "" {
+		t.Error("Expected a new family ID when none is given")
+	}
+	if !token.ExpiresAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("ExpiresAt = %v, want %v", token.ExpiresAt, now.Add(time.Hour))
+	}
+
+	// ローテーションでは同じファミリーを引き継ぎ、トークン自体は毎回異なる
+	rotated, rotatedPlaintext, err := NewRefreshToken(1, 2, token.FamilyID, now, time.Hour)
+	if err != nil {
+		t.Fatalf("NewRefreshToken() error = %v", err)
+	}
+	if rotated.FamilyID != token.FamilyID {
+		t.Errorf("FamilyID = %q, want %q", rotated.FamilyID, token.FamilyID)
+	}
+	if rotatedPlaintext == plaintext {
+		t.Error("Expected a different token after rotation")
+	}
+
+	if _, _, err := NewRefreshToken(1, 0, "", now, time.Hour); err != ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken without an organization, got %v", err)
+	}
+}
+
+func TestRefreshTokenUse(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	newToken := func() *RefreshToken {
+		token, _, _ := NewRefreshToken(1, 2, "", now, time.Hour)
+		return token
+	}
+
+	token := newToken()
+	if err := token.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if token.UsedAt == nil {
+		t.Error("Expected UsedAt to be set")
+	}
+	if err := token.Use(now.Add(time.Minute)); err != ErrRefreshTokenReused {
+		t.Errorf("Expected ErrRefreshTokenReused on second use, got %v", err)
+	}
+
+	expired := newToken()
+	if err := expired.Use(now.Add(time.Hour)); err != ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken after expiry, got %v", err)
+	}
+
+	revoked := newToken()
+	revoked.RevokedAt = &now
+	if err := revoked.Use(now); err != ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken for a revoked token, got %v", err)
+	}
+}
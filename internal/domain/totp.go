@@ -0,0 +1,138 @@
+package domain
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP のパラメーター（RFC 6238 の既定値で、一般的な認証アプリが対応している組み合わせ）
+const (
+	totpPeriod = 30 * time.Second
+	totpDigits = 6
+	// totpSkew 時計のずれを許容するため前後に受け付ける時間ステップ数
+	totpSkew = 1
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// GenerateTOTPSecret 160ビットの TOTP 秘密鍵を Base32 で生成
+func GenerateTOTPSecret() (string, error) {
+	b := make([]byte, 20)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return totpEncoding.EncodeToString(b), nil
+}
+
+// TOTPProvisioningURI 認証アプリに登録するための otpauth:// URI
+func TOTPProvisioningURI(issuer, accountName, secret string) string {
+	label := url.PathEscape(issuer + ":" + accountName)
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(int(totpPeriod/time.Second)))
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// totpStep 時刻に対応する時間ステップ
+func totpStep(t time.Time) int64 {
+	return t.Unix() / int64(totpPeriod/time.Second)
+}
+
+// totpCode 時間ステップのコード（RFC 4226 の HOTP）
+func totpCode(key []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000)
+}
+
+// matchTOTP コードが now の前後 totpSkew ステップのいずれかと一致すれば、その時間ステップを返す
+func matchTOTP(secret, code string, now time.Time) (int64, bool) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil || len(code) != totpDigits {
+		return 0, false
+	}
+
+	current := totpStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// IsTOTPEnabled 2段階認証（TOTP）を有効にしているかチェック
+func (u *User) IsTOTPEnabled() bool {
+	return u.TOTPEnabledAt != nil
+}
+
+// BeginTOTPEnrollment 新しい秘密鍵で TOTP の登録を開始する
+// 確認コードで ConfirmTOTP するまではログインに2段階認証は求めない
+func (u *User) BeginTOTPEnrollment(secret string) error {
+	if u.IsTOTPEnabled() {
+		return ErrTOTPAlreadyEnabled
+	}
+	u.TOTPSecret = secret
+	u.TOTPLastUsedStep = 0
+	return nil
+}
+
+// ConfirmTOTP 認証アプリが生成したコードで登録を確認し、2段階認証を有効にする
+func (u *User) ConfirmTOTP(code string, now time.Time) error {
+	if u.IsTOTPEnabled() {
+		return ErrTOTPAlreadyEnabled
+	}
+	if u.TOTPSecret == "" {
+		return ErrTOTPNotEnrolled
+	}
+
+	err := u.useTOTPCode(code, now)
+	if err != nil {
+		return err
+	}
+	u.TOTPEnabledAt = &now
+	return nil
+}
+
+// VerifyTOTP 有効にした TOTP のコードを検証する
+// 同じ時間ステップ以前のコードは一度使うと再利用できない
+func (u *User) VerifyTOTP(code string, now time.Time) error {
+	if !u.IsTOTPEnabled() {
+		return ErrTOTPNotEnabled
+	}
+	return u.useTOTPCode(code, now)
+}
+
+// DisableTOTP 2段階認証を無効にして秘密鍵を破棄する
+func (u *User) DisableTOTP() {
+	u.TOTPSecret = ""
+	u.TOTPEnabledAt = nil
+	u.TOTPLastUsedStep = 0
+}
+
+func (u *User) useTOTPCode(code string, now time.Time) error {
+	step, ok := matchTOTP(u.TOTPSecret, code, now)
+	if !ok || step <= u.TOTPLastUsedStep {
+		return ErrInvalidMFACode
+	}
+	u.TOTPLastUsedStep = step
+	return nil
+}
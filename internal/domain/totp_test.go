@@ -0,0 +1,194 @@
+package domain
+
+import (
+	"encoding/base32"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 付録 B の SHA-1 のテストベクター（8桁の値の下6桁）
+func TestTOTPCodeRFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		if got := totpCode(key, totpStep(time.Unix(tt.unix, 0))); got != tt.want {
+			t.Errorf("totpCode(T=%d) = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Reservation System", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	if parsed.Scheme != "otpauth" || parsed.Host != "totp" {
+		t.Errorf("Expected an otpauth://totp/ URI, got %s", uri)
+	}
+	if parsed.Path != "/Reservation System:user@example.com" {
+		t.Errorf("Expected the label to be issuer:account, got %q", parsed.Path)
+	}
+	query := parsed.Query()
+	if query.Get("secret") != "JBSWY3DPEHPK3PXP" || query.Get("issuer") != "Reservation System" {
+		t.Errorf("Expected secret and issuer parameters, got %s", parsed.RawQuery)
+	}
+}
+
+func TestUserTOTPEnrollment(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+
+	if err := user.ConfirmTOTP("000000", now); err != ErrTOTPNotEnrolled {
+		t.Errorf("Expected ErrTOTPNotEnrolled before enrollment, got %v", err)
+	}
+
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("GenerateTOTPSecret() error = %v", err)
+	}
+	if err := user.BeginTOTPEnrollment(secret); err != nil {
+		t.Fatalf("BeginTOTPEnrollment() error = %v", err)
+	}
+	if user.IsTOTPEnabled() {
+		t.Fatal("Expected TOTP to stay disabled until confirmed")
+	}
+
+	if err := user.ConfirmTOTP(codeAt(t, secret, now.Add(-5*time.Minute)), now); err != ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a stale code, got %v", err)
+	}
+	if err := user.ConfirmTOTP(codeAt(t, secret, now), now); err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+	if !user.IsTOTPEnabled() {
+		t.Fatal("Expected TOTP to be enabled after confirmation")
+	}
+	if err := user.BeginTOTPEnrollment(secret); err != ErrTOTPAlreadyEnabled {
+		t.Errorf("Expected ErrTOTPAlreadyEnabled, got %v", err)
+	}
+
+	user.DisableTOTP()
+	if user.IsTOTPEnabled() || user.TOTPSecret != "" {
+		t.Error("Expected DisableTOTP() to clear the secret")
+	}
+}
+
+func TestUserVerifyTOTP(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+	secret, _ := GenerateTOTPSecret()
+
+	if err := user.VerifyTOTP(codeAt(t, secret, now), now); err != ErrTOTPNotEnabled {
+		t.Errorf("Expected ErrTOTPNotEnabled, got %v", err)
+	}
+
+	_ = user.BeginTOTPEnrollment(secret)
+	if err := user.ConfirmTOTP(codeAt(t, secret, now), now); err != nil {
+		t.Fatalf("ConfirmTOTP() error = %v", err)
+	}
+
+	// 確認に使ったコードは再利用できない
+	if err := user.VerifyTOTP(codeAt(t, secret, now), now); err != ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a reused code, got %v", err)
+	}
+
+	// 時計のずれは前後1ステップまで許容する
+	later := now.Add(time.Minute)
+	if err := user.VerifyTOTP(codeAt(t, secret, later.Add(totpPeriod)), later); err != nil {
+		t.Errorf("Expected a code one step ahead to be accepted, got %v", err)
+	}
+	if err := user.VerifyTOTP(codeAt(t, secret, later.Add(5*totpPeriod)), later); err != ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a code outside the window, got %v", err)
+	}
+	if err := user.VerifyTOTP("12345", later); err != ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a malformed code, got %v", err)
+	}
+}
+
+func TestNewRecoveryCodes(t *testing.T) {
+	codes, plaintexts, err := NewRecoveryCodes(1)
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes() error = %v", err)
+	}
+	if len(codes) != RecoveryCodeCount || len(plaintexts) != RecoveryCodeCount {
+		t.Fatalf("Expected %d codes, got %d", RecoveryCodeCount, len(codes))
+	}
+
+	seen := make(map[string]bool)
+	for i, plaintext := range plaintexts {
+		if len(plaintext) != 11 || plaintext[5] != '-' {
+			t.Errorf("Expected xxxxx-xxxxx format, got %q", plaintext)
+		}
+		if seen[plaintext] {
+			t.Errorf("Duplicate recovery code %q", plaintext)
+		}
+		seen[plaintext] = true
+
+		if codes[i].CodeHash != HashRecoveryCode(plaintext) {
+			t.Error("Expected only the hash of the plaintext code to be stored")
+		}
+	}
+
+	// 入力時の大文字・小文字と区切り文字の違いは無視する
+	lenient := strings.ToLower(strings.Replace(plaintexts[0], "-", " ", 1))
+	if HashRecoveryCode(lenient) != codes[0].CodeHash {
+		t.Errorf("Expected %q to match %q", lenient, plaintexts[0])
+	}
+}
+
+func TestMFAChallengeUse(t *testing.T) {
+	now := time.Date(2026, 1, 12, 9, 0, 0, 0, time.UTC)
+
+	challenge, plaintext, err := NewMFAChallenge(1, 1, now, 5*time.Minute)
+	if err != nil {
+		t.Fatalf("NewMFAChallenge() error = %v", err)
+	}
+	if challenge.TokenHash != HashToken(plaintext) {
+		t.Error("Expected only the hash of the plaintext token to be stored")
+	}
+
+	if err := challenge.Valid(now.Add(5 * time.Minute)); err != ErrInvalidMFAChallenge {
+		t.Errorf("Expected ErrInvalidMFAChallenge after expiry, got %v", err)
+	}
+
+	challenge.Attempts = MaxMFAAttempts
+	if err := challenge.Valid(now); err != ErrInvalidMFAChallenge {
+		t.Errorf("Expected ErrInvalidMFAChallenge after %d failed attempts, got %v", MaxMFAAttempts, err)
+	}
+
+	challenge.Attempts = MaxMFAAttempts - 1
+	if err := challenge.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	if err := challenge.Use(now); err != ErrInvalidMFAChallenge {
+		t.Errorf("Expected ErrInvalidMFAChallenge on second use, got %v", err)
+	}
+
+	if _, _, err := NewMFAChallenge(1, 0, now, 5*time.Minute); err != ErrInvalidMFAChallenge {
+		t.Errorf("Expected ErrInvalidMFAChallenge without an organization, got %v", err)
+	}
+}
+
+// codeAt secret の時刻 at におけるコード
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		t.Fatalf("invalid secret: %v", err)
+	}
+	return totpCode(key, totpStep(at))
+}
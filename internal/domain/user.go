@@ -6,13 +6,23 @@ import (
 )
 
 // User ユーザーエンティティ
+// EmailVerifiedAt はメールアドレスを確認した日時で、未確認のユーザーは予約を作成できない
+// TOTPEnabledAt は2段階認証を有効にした日時で、有効なユーザーはログイン時に TOTP のコードが必要になる
+// TOTPLastUsedStep は最後に受け付けたコードの時間ステップで、同じコードの再利用を防ぐ
+// FailedAttempts は続けてログインに失敗した回数で、LockedUntil まではログインを受け付けない
 type User struct {
-	ID        uint      `json:"id" gorm:"primaryKey"`
-	Email     string    `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string    `json:"-" gorm:"not null"`
-	Name      string    `json:"name" gorm:"not null"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint       `json:"id" gorm:"primaryKey"`
+	Email            string     `json:"email" gorm:"uniqueIndex;not null"`
+	Password         string     `json:"-" gorm:"not null"`
+	Name             string     `json:"name" gorm:"not null"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	TOTPSecret       string     `json:"-"`
+	TOTPEnabledAt    *time.Time `json:"totp_enabled_at"`
+	TOTPLastUsedStep int64      `json:"-" gorm:"not null;default:0"`
+	FailedAttempts   int        `json:"-" gorm:"not null;default:0"`
+	LockedUntil      *time.Time `json:"locked_until"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
 }
 
 // NewUser 新規ユーザーを作成
@@ -29,6 +39,30 @@ func NewUser(email, password, name string) (*User, error) {
 	}, nil
 }
 
+// IsEmailVerified メールアドレスを確認済みかチェック
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// VerifyEmail メールアドレスを確認済みにする
+func (u *User) VerifyEmail(now time.Time) error {
+	if u.IsEmailVerified() {
+		return ErrEmailAlreadyVerified
+	}
+	u.EmailVerifiedAt = &now
+	return nil
+}
+
+// SetPassword パスワードをハッシュ化して変更
+func (u *User) SetPassword(password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	u.Password = string(hashedPassword)
+	return nil
+}
+
 // CheckPassword パスワードを検証
 func (u *User) CheckPassword(password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
@@ -70,3 +70,20 @@ func TestUserPasswordHashing(t *testing.T) {
 		t.Error("CheckPassword() should fail for wrong password")
 	}
 }
+
+func TestUserSetPassword(t *testing.T) {
+	user, _ := NewUser("test@example.com", "password123", "Test User")
+
+	if err := user.SetPassword("new-password"); err != nil {
+		t.Fatalf("SetPassword() error = %v", err)
+	}
+	if user.Password == "new-password" {
+		t.Error("Password should be hashed, not stored in plaintext")
+	}
+	if err := user.CheckPassword("new-password"); err != nil {
+		t.Errorf("CheckPassword() with the new password error = %v", err)
+	}
+	if err := user.CheckPassword("password123"); err == nil {
+		t.Error("CheckPassword() should fail for the old password")
+	}
+}
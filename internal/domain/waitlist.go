@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+)
+
+// WaitlistStatus キャンセル待ちステータス
+type WaitlistStatus string
+
+const (
+	WaitlistStatusWaiting  WaitlistStatus = "waiting"
+	WaitlistStatusPromoted WaitlistStatus = "promoted"
+	WaitlistStatusLeft     WaitlistStatus = "left"
+)
+
+// WaitlistEntry 満席の時間枠に対するキャンセル待ちエンティティ
+// 登録順（ID順）に繰り上げる
+type WaitlistEntry struct {
+	ID             uint           `json:"id" gorm:"primaryKey"`
+	OrganizationID uint           `json:"organization_id" gorm:"index"`
+	UserID         uint           `json:"user_id" gorm:"not null;index"`
+	ResourceID     uint           `json:"resource_id" gorm:"not null;index"`
+	TimeSlot       *TimeSlot      `json:"time_slot" gorm:"embedded"`
+	Quantity       int            `json:"quantity" gorm:"not null;default:1"`
+	Status         WaitlistStatus `json:"status" gorm:"not null;default:'waiting'"`
+	ReservationID  *uint          `json:"reservation_id,omitempty"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+}
+
+// NewWaitlistEntry 新規キャンセル待ちを作成
+func NewWaitlistEntry(userID, resourceID uint, timeSlot *TimeSlot, quantity int) (*WaitlistEntry, error) {
+	// 繰り上げ時に作成する予約と同じ条件で検証する
+	if _, err := NewReservation(userID, resourceID, timeSlot, quantity); err != nil {
+		return nil, err
+	}
+
+	return &WaitlistEntry{
+		UserID:     userID,
+		ResourceID: resourceID,
+		TimeSlot:   timeSlot,
+		Quantity:   quantity,
+		Status:     WaitlistStatusWaiting,
+	}, nil
+}
+
+// NewReservation 繰り上げ用の仮予約を作成
+func (e *WaitlistEntry) NewReservation() (*Reservation, error) {
+	if e.Status != WaitlistStatusWaiting {
+		return nil, ErrWaitlistEntryNotWaiting
+	}
+	reservation, err := NewReservation(e.UserID, e.ResourceID, e.TimeSlot, e.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	reservation.OrganizationID = e.OrganizationID
+	return reservation, nil
+}
+
+// Promote 予約へ繰り上げ済みにする
+func (e *WaitlistEntry) Promote(reservationID uint) error {
+	if e.Status != WaitlistStatusWaiting {
+		return ErrWaitlistEntryNotWaiting
+	}
+	e.Status = WaitlistStatusPromoted
+	e.ReservationID = &reservationID
+	return nil
+}
+
+// Leave キャンセル待ちを取り下げ
+func (e *WaitlistEntry) Leave() error {
+	if e.Status != WaitlistStatusWaiting {
+		return ErrWaitlistEntryNotWaiting
+	}
+	e.Status = WaitlistStatusLeft
+	return nil
+}
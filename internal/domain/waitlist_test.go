@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewWaitlistEntry(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Now(), "09:00", "10:00", 4)
+
+	entry, err := NewWaitlistEntry(1, 1, ts, 2)
+	if err != nil {
+		t.Fatalf("NewWaitlistEntry() error = %v", err)
+	}
+	if entry.Status != WaitlistStatusWaiting {
+		t.Errorf("Expected status %s, got %s", WaitlistStatusWaiting, entry.Status)
+	}
+
+	if _, err := NewWaitlistEntry(1, 1, ts, 5); err != ErrCapacityExceeded {
+		t.Errorf("Expected ErrCapacityExceeded for quantity above capacity, got %v", err)
+	}
+	if _, err := NewWaitlistEntry(0, 1, ts, 1); err != ErrInvalidUser {
+		t.Errorf("Expected ErrInvalidUser, got %v", err)
+	}
+}
+
+func TestWaitlistEntryPromote(t *testing.T) {
+	ts, _ := NewTimeSlot(time.Now(), "09:00", "10:00", 4)
+	entry, _ := NewWaitlistEntry(1, 1, ts, 2)
+
+	reservation, err := entry.NewReservation()
+	if err != nil {
+		t.Fatalf("NewReservation() error = %v", err)
+	}
+	if reservation.Status != StatusPending || reservation.Quantity != 2 {
+		t.Errorf("Expected pending reservation for 2 seats, got %s for %d", reservation.Status, reservation.Quantity)
+	}
+
+	if err := entry.Promote(42); err != nil {
+		t.Fatalf("Promote() error = %v", err)
+	}
+	if entry.Status != WaitlistStatusPromoted || entry.ReservationID == nil || *entry.ReservationID != 42 {
+		t.Errorf("Expected promoted entry linked to reservation 42, got %+v", entry)
+	}
+
+	if _, err := entry.NewReservation(); err != ErrWaitlistEntryNotWaiting {
+		t.Errorf("Expected ErrWaitlistEntryNotWaiting, got %v", err)
+	}
+	if err := entry.Leave(); err != ErrWaitlistEntryNotWaiting {
+		t.Errorf("Expected ErrWaitlistEntryNotWaiting, got %v", err)
+	}
+}
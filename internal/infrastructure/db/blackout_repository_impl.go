@@ -0,0 +1,77 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type blackoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewBlackoutRepository 休業日リポジトリを実装
+func NewBlackoutRepository() repository.BlackoutRepository {
+	return &blackoutRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *blackoutRepositoryImpl) Create(blackout *domain.BlackoutDate) error {
+	return r.db.Create(blackout).Error
+}
+
+// CreateAll 複数の休業日を1トランザクションで作成
+func (r *blackoutRepositoryImpl) CreateAll(blackouts []*domain.BlackoutDate) error {
+	if len(blackouts) == 0 {
+		return nil
+	}
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return tx.Create(&blackouts).Error
+	})
+}
+
+func (r *blackoutRepositoryImpl) FindByID(organizationID, id uint) (*domain.BlackoutDate, error) {
+	var blackout domain.BlackoutDate
+	err := r.db.Scopes(inOrganization(organizationID)).First(&blackout, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrBlackoutNotFound
+		}
+		return nil, err
+	}
+	return &blackout, nil
+}
+
+// FindAll 組織の休業日一覧を取得（resourceID 指定時はそのリソース専用の休業日のみ）
+func (r *blackoutRepositoryImpl) FindAll(organizationID uint, resourceID *uint) ([]*domain.BlackoutDate, error) {
+	var blackouts []*domain.BlackoutDate
+	query := r.db.Scopes(inOrganization(organizationID)).Order("date, id")
+	if resourceID != nil {
+		query = query.Where("resource_id = ?", *resourceID)
+	}
+	err := query.Find(&blackouts).Error
+	return blackouts, err
+}
+
+// FindForResource 期間内（from, to とも含む）にリソースへ適用される組織共通・個別の休業日を取得
+func (r *blackoutRepositoryImpl) FindForResource(organizationID, resourceID uint, from, to time.Time) (domain.Blackouts, error) {
+	var blackouts domain.Blackouts
+	err := r.db.Scopes(inOrganization(organizationID)).
+		Where("resource_id IS NULL OR resource_id = ?", resourceID).
+		Where("date >= ? AND date < ?", from, to.AddDate(0, 0, 1)).
+		Order("date, id").
+		Find(&blackouts).Error
+	return blackouts, err
+}
+
+func (r *blackoutRepositoryImpl) Update(blackout *domain.BlackoutDate) error {
+	return r.db.Save(blackout).Error
+}
+
+func (r *blackoutRepositoryImpl) Delete(organizationID, id uint) error {
+	return r.db.Scopes(inOrganization(organizationID)).Delete(&domain.BlackoutDate{}, id).Error
+}
@@ -34,15 +34,58 @@ func InitDatabase() error {
 		return fmt.Errorf("failed to connect database: %w", err)
 	}
 
+	// メールアドレス確認の導入前からいるユーザーは、列を追加するときに一度だけ確認済みとして扱う
+	backfillEmailVerified := !DB.Migrator().HasColumn(&domain.User{}, "email_verified_at")
+
+	// 権限を組織ごとに持つ前は users.role に保存していたため、列を追加するときに一度だけ各所属へ写す
+	backfillMembershipRoles := DB.Migrator().HasColumn("users", "role") && !DB.Migrator().HasColumn(&domain.Membership{}, "role")
+
 	// 自動マイグレーション
 	err = DB.AutoMigrate(
+		&domain.Organization{},
+		&domain.Membership{},
 		&domain.User{},
+		&domain.Resource{},
+		&domain.OpeningHours{},
+		&domain.CancellationTier{},
+		&domain.ResourceApprover{},
+		&domain.ResourceStaff{},
+		&domain.ReservationSeries{},
 		&domain.Reservation{},
+		&domain.WaitlistEntry{},
+		&domain.BlackoutDate{},
+		&domain.RefreshToken{},
+		&domain.RevokedToken{},
+		&domain.PasswordResetToken{},
+		&domain.EmailVerificationToken{},
+		&domain.MFAChallenge{},
+		&domain.RecoveryCode{},
+		&domain.LoginFailure{},
 	)
 	if err != nil {
 		return fmt.Errorf("failed to migrate database: %w", err)
 	}
 
+	if err := backfillSlotInstants(DB); err != nil {
+		return fmt.Errorf("failed to backfill time slots: %w", err)
+	}
+
+	if err := backfillDefaultOrganization(DB); err != nil {
+		return fmt.Errorf("failed to backfill organizations: %w", err)
+	}
+
+	if backfillEmailVerified {
+		if err := DB.Exec(`UPDATE users SET email_verified_at = created_at WHERE email_verified_at IS NULL`).Error; err != nil {
+			return fmt.Errorf("failed to backfill email verification: %w", err)
+		}
+	}
+
+	if backfillMembershipRoles {
+		if err := DB.Exec(`UPDATE memberships SET role = users.role FROM users WHERE users.id = memberships.user_id`).Error; err != nil {
+			return fmt.Errorf("failed to backfill membership roles: %w", err)
+		}
+	}
+
 	log.Println("Database connected and migrated successfully")
 	return nil
 }
@@ -51,3 +94,48 @@ func InitDatabase() error {
 func GetDB() *gorm.DB {
 	return DB
 }
+
+// inOrganization organization_id 列を持つテーブルを組織の行だけに絞り込む
+func inOrganization(organizationID uint) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("organization_id = ?", organizationID)
+	}
+}
+
+// backfillSlotInstants start_at/end_at 列の追加前に作成された時間枠を UTC のローカル時刻として補完
+// end_date 列の追加前の時間枠はすべて開始日に終わる
+func backfillSlotInstants(db *gorm.DB) error {
+	for _, table := range []string{"reservations", "waitlist_entries"} {
+		err := db.Exec(`UPDATE ` + table + ` SET
+			start_at = ((date AT TIME ZONE 'UTC')::date + start_time::time) AT TIME ZONE 'UTC',
+			end_at = ((date AT TIME ZONE 'UTC')::date + end_time::time) AT TIME ZONE 'UTC'
+			WHERE start_at IS NULL`).Error
+		if err != nil {
+			return err
+		}
+		if err := db.Exec(`UPDATE ` + table + ` SET end_date = date WHERE end_date IS NULL`).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backfillDefaultOrganization 組織の導入前に作成されたデータを既定の組織へ移行
+// 既定の組織がなければ作成し、組織のないリソース・予約などと所属のないユーザーをそこへ割り当てる
+func backfillDefaultOrganization(db *gorm.DB) error {
+	organization := domain.Organization{Name: "Default", Slug: domain.DefaultOrganizationSlug}
+	if err := db.Where("slug = ?", organization.Slug).FirstOrCreate(&organization).Error; err != nil {
+		return err
+	}
+
+	for _, table := range []string{"resources", "reservations", "reservation_series", "waitlist_entries", "blackout_dates"} {
+		err := db.Exec(`UPDATE `+table+` SET organization_id = ? WHERE organization_id IS NULL OR organization_id = 0`, organization.ID).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return db.Exec(`INSERT INTO memberships (organization_id, user_id, created_at)
+		SELECT ?, users.id, NOW() FROM users
+		WHERE NOT EXISTS (SELECT 1 FROM memberships WHERE memberships.user_id = users.id)`, organization.ID).Error
+}
@@ -0,0 +1,64 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type emailVerificationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewEmailVerificationRepository メールアドレス確認トークンリポジトリを実装
+func NewEmailVerificationRepository() repository.EmailVerificationRepository {
+	return &emailVerificationRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *emailVerificationRepositoryImpl) Create(token *domain.EmailVerificationToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByHash ハッシュからトークンを取得（見つからない場合は ErrInvalidVerificationToken）
+func (r *emailVerificationRepositoryImpl) FindByHash(tokenHash string) (*domain.EmailVerificationToken, error) {
+	var token domain.EmailVerificationToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInvalidVerificationToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *emailVerificationRepositoryImpl) FindCreatedSince(userID uint, since time.Time) ([]*domain.EmailVerificationToken, error) {
+	var tokens []*domain.EmailVerificationToken
+	err := r.db.Where("user_id = ? AND created_at >= ?", userID, since).
+		Order("created_at DESC").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// MarkUsed used_at が NULL の行だけを更新する比較交換
+func (r *emailVerificationRepositoryImpl) MarkUsed(token *domain.EmailVerificationToken) (bool, error) {
+	result := r.db.Model(&domain.EmailVerificationToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", token.UsedAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *emailVerificationRepositoryImpl) InvalidateByUserID(userID uint, now time.Time) error {
+	return r.db.Model(&domain.EmailVerificationToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", now).Error
+}
+
+func (r *emailVerificationRepositoryImpl) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&domain.EmailVerificationToken{}).Error
+}
@@ -0,0 +1,92 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestEmailVerificationTokenSingleUse(t *testing.T) {
+	setupTestDatabase(t)
+
+	const userID = 4343
+	repo := NewEmailVerificationRepository()
+	now := time.Now()
+	t.Cleanup(func() {
+		DB.Where("user_id = ?", userID).Delete(&domain.EmailVerificationToken{})
+	})
+
+	first, _, _ := domain.NewEmailVerificationToken(userID, now.Add(-time.Hour), 24*time.Hour)
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	second, _, _ := domain.NewEmailVerificationToken(userID, now, 24*time.Hour)
+	if err := repo.Create(second); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 再送制限には指定した日時以降に発行したトークンだけを使う
+	recent, err := repo.FindCreatedSince(userID, now.Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("FindCreatedSince() error = %v", err)
+	}
+	if len(recent) != 1 || recent[0].ID != second.ID {
+		t.Errorf("Expected only the latest token, got %d tokens", len(recent))
+	}
+
+	stored, _ := repo.FindByHash(second.TokenHash)
+	if err := stored.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	replica, _ := repo.FindByHash(second.TokenHash)
+	_ = replica.Use(now)
+
+	if used, err := repo.MarkUsed(stored); err != nil || !used {
+		t.Fatalf("MarkUsed() = %v, %v; want true", used, err)
+	}
+	if used, _ := repo.MarkUsed(replica); used {
+		t.Error("Expected MarkUsed() to fail for a token another request already used")
+	}
+
+	// 確認が済んだら残りのトークンも使えなくする
+	if err := repo.InvalidateByUserID(userID, now); err != nil {
+		t.Fatalf("InvalidateByUserID() error = %v", err)
+	}
+	stored, _ = repo.FindByHash(first.TokenHash)
+	if err := stored.Use(now); err != domain.ErrInvalidVerificationToken {
+		t.Errorf("Expected the earlier token to be invalidated, got %v", err)
+	}
+
+	if _, err := repo.FindByHash(domain.HashToken("unknown")); err != domain.ErrInvalidVerificationToken {
+		t.Errorf("Expected ErrInvalidVerificationToken for an unknown token, got %v", err)
+	}
+}
+
+func TestUserMarkEmailVerifiedOnce(t *testing.T) {
+	setupTestDatabase(t)
+
+	user, _ := domain.NewUser(fmt.Sprintf("verify-%d@example.com", time.Now().UnixNano()), "password123", "Verify")
+	repo := NewUserRepository()
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Unscoped().Delete(user)
+	})
+
+	now := time.Now()
+	_ = user.VerifyEmail(now)
+	if verified, err := repo.MarkEmailVerified(user); err != nil || !verified {
+		t.Fatalf("MarkEmailVerified() = %v, %v; want true, nil", verified, err)
+	}
+	if verified, _ := repo.MarkEmailVerified(user); verified {
+		t.Error("Expected MarkEmailVerified() to fail for a verified user")
+	}
+
+	stored, _ := repo.FindByID(user.ID)
+	if !stored.IsEmailVerified() || stored.Password != user.Password {
+		t.Errorf("Expected only email_verified_at to change, got %+v", stored)
+	}
+}
@@ -0,0 +1,42 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type loginFailureRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLoginFailureRepository IP アドレスごとのログイン失敗リポジトリを実装
+func NewLoginFailureRepository() repository.LoginFailureRepository {
+	return &loginFailureRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *loginFailureRepositoryImpl) Create(failure *domain.LoginFailure) error {
+	return r.db.Create(failure).Error
+}
+
+func (r *loginFailureRepositoryImpl) CountSince(ipAddress string, since time.Time) (int, time.Time, error) {
+	var result struct {
+		Count int
+		Last  sql.NullTime
+	}
+	err := r.db.Model(&domain.LoginFailure{}).
+		Select("COUNT(*) AS count, MAX(created_at) AS last").
+		Where("ip_address = ? AND created_at >= ?", ipAddress, since).
+		Scan(&result).Error
+	return result.Count, result.Last.Time, err
+}
+
+func (r *loginFailureRepositoryImpl) DeleteBefore(cutoff time.Time) error {
+	return r.db.Where("created_at < ?", cutoff).Delete(&domain.LoginFailure{}).Error
+}
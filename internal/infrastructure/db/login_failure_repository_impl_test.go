@@ -0,0 +1,80 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestLoginFailureCountSince(t *testing.T) {
+	setupTestDatabase(t)
+
+	ipAddress := fmt.Sprintf("192.0.2.%d", time.Now().UnixNano()%250+1)
+	repo := NewLoginFailureRepository()
+	now := time.Now().Truncate(time.Second)
+	t.Cleanup(func() {
+		DB.Where("ip_address = ?", ipAddress).Delete(&domain.LoginFailure{})
+	})
+
+	if count, _, err := repo.CountSince(ipAddress, now.Add(-time.Hour)); err != nil || count != 0 {
+		t.Fatalf("CountSince() = %d, %v; want 0", count, err)
+	}
+
+	for _, at := range []time.Time{now.Add(-2 * time.Hour), now.Add(-time.Minute), now} {
+		if err := repo.Create(&domain.LoginFailure{IPAddress: ipAddress, CreatedAt: at}); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	count, last, err := repo.CountSince(ipAddress, now.Add(-time.Hour))
+	if err != nil {
+		t.Fatalf("CountSince() error = %v", err)
+	}
+	if count != 2 || !last.Equal(now) {
+		t.Errorf("CountSince() = %d, %v; want 2, %v", count, last, now)
+	}
+}
+
+func TestUserIncrementFailedAttempts(t *testing.T) {
+	setupTestDatabase(t)
+
+	user, _ := domain.NewUser(fmt.Sprintf("lockout-%d@example.com", time.Now().UnixNano()), "password123", "Lockout")
+	repo := NewUserRepository()
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Unscoped().Delete(user)
+	})
+
+	// 同時に失敗しても回数を取りこぼさない
+	const attempts = 5
+	done := make(chan int, attempts)
+	for i := 0; i < attempts; i++ {
+		go func() {
+			n, err := repo.IncrementFailedAttempts(user.ID)
+			if err != nil {
+				t.Errorf("IncrementFailedAttempts() error = %v", err)
+			}
+			done <- n
+		}()
+	}
+	seen := make(map[int]bool)
+	for i := 0; i < attempts; i++ {
+		seen[<-done] = true
+	}
+	if len(seen) != attempts {
+		t.Errorf("Expected each increment to return a distinct count, got %v", seen)
+	}
+
+	user.ClearFailedLogins()
+	if err := repo.UpdateLockout(user); err != nil {
+		t.Fatalf("UpdateLockout() error = %v", err)
+	}
+	stored, _ := repo.FindByID(user.ID)
+	if stored.FailedAttempts != 0 || stored.LockedUntil != nil {
+		t.Errorf("Expected the lockout to be cleared, got %d attempts until %v", stored.FailedAttempts, stored.LockedUntil)
+	}
+}
@@ -0,0 +1,106 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type mfaChallengeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMFAChallengeRepository MFA チャレンジリポジトリを実装
+func NewMFAChallengeRepository() repository.MFAChallengeRepository {
+	return &mfaChallengeRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *mfaChallengeRepositoryImpl) Create(challenge *domain.MFAChallenge) error {
+	return r.db.Create(challenge).Error
+}
+
+// FindByHash ハッシュからチャレンジを取得（見つからない場合は ErrInvalidMFAChallenge）
+func (r *mfaChallengeRepositoryImpl) FindByHash(tokenHash string) (*domain.MFAChallenge, error) {
+	var challenge domain.MFAChallenge
+	err := r.db.Where("token_hash = ?", tokenHash).First(&challenge).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInvalidMFAChallenge
+		}
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// MarkUsed used_at が NULL の行だけを更新する比較交換
+func (r *mfaChallengeRepositoryImpl) MarkUsed(challenge *domain.MFAChallenge) (bool, error) {
+	result := r.db.Model(&domain.MFAChallenge{}).
+		Where("id = ? AND used_at IS NULL", challenge.ID).
+		Update("used_at", challenge.UsedAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+// ReserveAttempt 上限の確認と加算を1つの UPDATE で行い、同時に送られたコードが上限を超えて確認されないようにする
+func (r *mfaChallengeRepositoryImpl) ReserveAttempt(challenge *domain.MFAChallenge) (bool, error) {
+	result := r.db.Model(&domain.MFAChallenge{}).
+		Where("id = ? AND attempts < ? AND used_at IS NULL", challenge.ID, domain.MaxMFAAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *mfaChallengeRepositoryImpl) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&domain.MFAChallenge{}).Error
+}
+
+type recoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository リカバリーコードリポジトリを実装
+func NewRecoveryCodeRepository() repository.RecoveryCodeRepository {
+	return &recoveryCodeRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+// ReplaceForUser リカバリーコードを全件入れ替え
+func (r *recoveryCodeRepositoryImpl) ReplaceForUser(userID uint, codes []*domain.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *recoveryCodeRepositoryImpl) FindUnused(userID uint, codeHash string) (*domain.RecoveryCode, error) {
+	var code domain.RecoveryCode
+	err := r.db.Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).First(&code).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInvalidMFACode
+		}
+		return nil, err
+	}
+	return &code, nil
+}
+
+// MarkUsed used_at が NULL の行だけを更新する比較交換
+func (r *recoveryCodeRepositoryImpl) MarkUsed(code *domain.RecoveryCode, now time.Time) (bool, error) {
+	result := r.db.Model(&domain.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", code.ID).
+		Update("used_at", now)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *recoveryCodeRepositoryImpl) DeleteByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&domain.RecoveryCode{}).Error
+}
@@ -0,0 +1,189 @@
+package db
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestMFAChallengeAttempts(t *testing.T) {
+	setupTestDatabase(t)
+
+	const userID = 4444
+	repo := NewMFAChallengeRepository()
+	now := time.Now()
+	t.Cleanup(func() {
+		DB.Where("user_id = ?", userID).Delete(&domain.MFAChallenge{})
+	})
+
+	challenge, _, _ := domain.NewMFAChallenge(userID, 1, now, 5*time.Minute)
+	if err := repo.Create(challenge); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 上限を超えて同時に予約しても、成功するのは MaxMFAAttempts 回のみ
+	results := make(chan bool, domain.MaxMFAAttempts*2)
+	var wg sync.WaitGroup
+	for i := 0; i < domain.MaxMFAAttempts*2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reserved, err := repo.ReserveAttempt(challenge)
+			if err != nil {
+				t.Errorf("ReserveAttempt() error = %v", err)
+			}
+			results <- reserved
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	reservedCount := 0
+	for reserved := range results {
+		if reserved {
+			reservedCount++
+		}
+	}
+	if reservedCount != domain.MaxMFAAttempts {
+		t.Errorf("Expected %d reserved attempts, got %d", domain.MaxMFAAttempts, reservedCount)
+	}
+
+	stored, err := repo.FindByHash(challenge.TokenHash)
+	if err != nil {
+		t.Fatalf("FindByHash() error = %v", err)
+	}
+	if stored.Attempts != domain.MaxMFAAttempts {
+		t.Errorf("Expected %d attempts, got %d", domain.MaxMFAAttempts, stored.Attempts)
+	}
+	if err := stored.Valid(now); err != domain.ErrInvalidMFAChallenge {
+		t.Errorf("Expected the challenge to be locked after too many attempts, got %v", err)
+	}
+
+	used, _, _ := domain.NewMFAChallenge(userID, 1, now, 5*time.Minute)
+	if err := repo.Create(used); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	used.Use(now)
+	if ok, _ := repo.MarkUsed(used); !ok {
+		t.Fatal("MarkUsed() = false, want true")
+	}
+	if reserved, _ := repo.ReserveAttempt(used); reserved {
+		t.Error("Expected no attempts to be reserved on a used challenge")
+	}
+
+	if _, err := repo.FindByHash(domain.HashToken("unknown")); err != domain.ErrInvalidMFAChallenge {
+		t.Errorf("Expected ErrInvalidMFAChallenge for an unknown token, got %v", err)
+	}
+}
+
+func TestRecoveryCodeSingleUse(t *testing.T) {
+	setupTestDatabase(t)
+
+	const userID = 4444
+	repo := NewRecoveryCodeRepository()
+	now := time.Now()
+	t.Cleanup(func() {
+		repo.DeleteByUserID(userID)
+	})
+
+	codes, plaintexts, _ := domain.NewRecoveryCodes(userID)
+	if err := repo.ReplaceForUser(userID, codes); err != nil {
+		t.Fatalf("ReplaceForUser() error = %v", err)
+	}
+
+	code, err := repo.FindUnused(userID, domain.HashRecoveryCode(plaintexts[0]))
+	if err != nil {
+		t.Fatalf("FindUnused() error = %v", err)
+	}
+	if used, err := repo.MarkUsed(code, now); err != nil || !used {
+		t.Fatalf("MarkUsed() = %v, %v; want true", used, err)
+	}
+	if used, _ := repo.MarkUsed(code, now); used {
+		t.Error("Expected MarkUsed() to fail for a code already used")
+	}
+	if _, err := repo.FindUnused(userID, domain.HashRecoveryCode(plaintexts[0])); err != domain.ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a used code, got %v", err)
+	}
+
+	// 入れ替え後は以前のコードを使えない
+	replacement, _, _ := domain.NewRecoveryCodes(userID)
+	if err := repo.ReplaceForUser(userID, replacement); err != nil {
+		t.Fatalf("ReplaceForUser() error = %v", err)
+	}
+	if _, err := repo.FindUnused(userID, domain.HashRecoveryCode(plaintexts[1])); err != domain.ErrInvalidMFACode {
+		t.Errorf("Expected ErrInvalidMFACode for a replaced code, got %v", err)
+	}
+}
+
+func TestTOTPStepSingleUse(t *testing.T) {
+	setupTestDatabase(t)
+
+	user, _ := domain.NewUser(fmt.Sprintf("totp-%d@example.com", time.Now().UnixNano()), "password123", "TOTP")
+	repo := NewUserRepository()
+	if err := repo.Create(user); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Unscoped().Delete(user)
+	})
+
+	user.BeginTOTPEnrollment("JBSWY3DPEHPK3PXP")
+	if err := repo.UpdateTOTP(user); err != nil {
+		t.Fatalf("UpdateTOTP() error = %v", err)
+	}
+
+	now := time.Now()
+	user.TOTPEnabledAt = &now
+	user.TOTPLastUsedStep = 100
+	if enabled, err := repo.EnableTOTP(user); err != nil || !enabled {
+		t.Fatalf("EnableTOTP() = %v, %v; want true", enabled, err)
+	}
+	if enabled, _ := repo.EnableTOTP(user); enabled {
+		t.Error("Expected EnableTOTP() to fail once 2FA is enabled")
+	}
+
+	if used, _ := repo.UseTOTPStep(user); used {
+		t.Error("Expected the step used for enrollment to be rejected")
+	}
+
+	// 同じ時間ステップで同時に認証しても、成功するのは1回のみ
+	user.TOTPLastUsedStep = 101
+	const requests = 5
+	results := make(chan bool, requests)
+	var wg sync.WaitGroup
+	for i := 0; i < requests; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			used, err := repo.UseTOTPStep(user)
+			if err != nil {
+				t.Errorf("UseTOTPStep() error = %v", err)
+			}
+			results <- used
+		}()
+	}
+	wg.Wait()
+	close(results)
+
+	usedCount := 0
+	for used := range results {
+		if used {
+			usedCount++
+		}
+	}
+	if usedCount != 1 {
+		t.Errorf("Expected exactly one request to use the step, got %d", usedCount)
+	}
+
+	user.DisableTOTP()
+	if err := repo.UpdateTOTP(user); err != nil {
+		t.Fatalf("UpdateTOTP() error = %v", err)
+	}
+	stored, _ := repo.FindByID(user.ID)
+	if stored.IsTOTPEnabled() || stored.TOTPSecret != "" || stored.TOTPLastUsedStep != 0 {
+		t.Errorf("Expected 2FA to be cleared, got %+v", stored)
+	}
+}
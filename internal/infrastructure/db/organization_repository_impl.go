@@ -0,0 +1,102 @@
+package db
+
+import (
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type organizationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository 組織リポジトリを実装
+func NewOrganizationRepository() repository.OrganizationRepository {
+	return &organizationRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+// Create 組織を作成（slug が重複する場合は ErrOrganizationExists）
+func (r *organizationRepositoryImpl) Create(organization *domain.Organization) error {
+	var count int64
+	if err := r.db.Model(&domain.Organization{}).Where("slug = ?", organization.Slug).Count(&count).Error; err != nil {
+		return err
+	}
+	if count > 0 {
+		return domain.ErrOrganizationExists
+	}
+	return r.db.Create(organization).Error
+}
+
+func (r *organizationRepositoryImpl) FindByID(id uint) (*domain.Organization, error) {
+	var organization domain.Organization
+	err := r.db.First(&organization, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+func (r *organizationRepositoryImpl) FindBySlug(slug string) (*domain.Organization, error) {
+	var organization domain.Organization
+	err := r.db.Where("slug = ?", slug).First(&organization).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrOrganizationNotFound
+		}
+		return nil, err
+	}
+	return &organization, nil
+}
+
+// FindByUserID ユーザーが所属する組織を所属した順に取得
+func (r *organizationRepositoryImpl) FindByUserID(userID uint) ([]*domain.Organization, error) {
+	var organizations []*domain.Organization
+	err := r.db.
+		Joins("JOIN memberships ON memberships.organization_id = organizations.id").
+		Where("memberships.user_id = ?", userID).
+		Order("memberships.id").
+		Find(&organizations).Error
+	return organizations, err
+}
+
+// AddMember ユーザーを組織に追加（所属済みの場合は何もしない）
+func (r *organizationRepositoryImpl) AddMember(membership *domain.Membership) error {
+	return r.db.Where("organization_id = ? AND user_id = ?", membership.OrganizationID, membership.UserID).
+		FirstOrCreate(membership).Error
+}
+
+func (r *organizationRepositoryImpl) RemoveMember(organizationID, userID uint) error {
+	return r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).Delete(&domain.Membership{}).Error
+}
+
+func (r *organizationRepositoryImpl) IsMember(organizationID, userID uint) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.Membership{}).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// FindMembership ユーザーの組織への所属を取得（所属していない場合は ErrNotOrganizationMember）
+func (r *organizationRepositoryImpl) FindMembership(organizationID, userID uint) (*domain.Membership, error) {
+	var membership domain.Membership
+	err := r.db.Where("organization_id = ? AND user_id = ?", organizationID, userID).First(&membership).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrNotOrganizationMember
+		}
+		return nil, err
+	}
+	return &membership, nil
+}
+
+// UpdateMemberRole 所属の権限だけを更新（他の組織での権限は変わらない）
+func (r *organizationRepositoryImpl) UpdateMemberRole(membership *domain.Membership) error {
+	return r.db.Model(membership).Update("role", membership.Role).Error
+}
@@ -0,0 +1,162 @@
+package db
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// createTestOrganization テスト用の組織を作成し、終了時に削除する
+func createTestOrganization(t *testing.T, name string) *domain.Organization {
+	t.Helper()
+	organization, err := domain.NewOrganization(name, fmt.Sprintf("%s-%d", name, time.Now().UnixNano()))
+	if err != nil {
+		t.Fatalf("NewOrganization() error = %v", err)
+	}
+	repo := NewOrganizationRepository()
+	if err := repo.Create(organization); err != nil {
+		t.Fatalf("Create organization error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("organization_id = ?", organization.ID).Delete(&domain.Membership{})
+		DB.Delete(organization)
+	})
+	return organization
+}
+
+func TestRepositoriesAreScopedToOrganization(t *testing.T) {
+	setupTestDatabase(t)
+
+	tenantA := createTestOrganization(t, "tenant-a")
+	tenantB := createTestOrganization(t, "tenant-b")
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Tenant Room", domain.ResourceTypeMeetingRoom, 1, "")
+	resource.OrganizationID = tenantA.ID
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(tenantA.ID, resource.ID)
+	})
+
+	ts, _ := domain.NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", resource.Capacity)
+	reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	reservation.OrganizationID = tenantA.ID
+	reservationRepo := NewReservationRepository()
+	if err := reservationRepo.CreateIfAvailable(reservation); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	if _, err := resourceRepo.FindByID(tenantA.ID, resource.ID); err != nil {
+		t.Errorf("FindByID() in the owning organization error = %v", err)
+	}
+	if _, err := resourceRepo.FindByID(tenantB.ID, resource.ID); err != domain.ErrResourceNotFound {
+		t.Errorf("Expected ErrResourceNotFound from another organization, got %v", err)
+	}
+	if _, err := reservationRepo.FindByID(tenantB.ID, reservation.ID); err != domain.ErrReservationNotFound {
+		t.Errorf("Expected ErrReservationNotFound from another organization, got %v", err)
+	}
+	if err := resourceRepo.Delete(tenantB.ID, resource.ID); err != domain.ErrResourceNotFound {
+		t.Errorf("Expected Delete() from another organization to fail with ErrResourceNotFound, got %v", err)
+	}
+
+	resources, err := resourceRepo.FindAll(tenantB.ID)
+	if err != nil {
+		t.Fatalf("FindAll() error = %v", err)
+	}
+	if len(resources) != 0 {
+		t.Errorf("Expected no resources in another organization, got %d", len(resources))
+	}
+
+	reservations, err := reservationRepo.FindByUserID(tenantB.ID, reservation.UserID)
+	if err != nil {
+		t.Fatalf("FindByUserID() error = %v", err)
+	}
+	for _, r := range reservations {
+		if r.ID == reservation.ID {
+			t.Error("FindByUserID() returned a reservation from another organization")
+		}
+	}
+}
+
+func TestOrganizationMembership(t *testing.T) {
+	setupTestDatabase(t)
+
+	organization := createTestOrganization(t, "members")
+	repo := NewOrganizationRepository()
+
+	duplicate, _ := domain.NewOrganization("Duplicate", organization.Slug)
+	if err := repo.Create(duplicate); err != domain.ErrOrganizationExists {
+		t.Errorf("Expected ErrOrganizationExists, got %v", err)
+	}
+
+	membership, _ := domain.NewMembership(organization.ID, 42)
+	if err := repo.AddMember(membership); err != nil {
+		t.Fatalf("AddMember() error = %v", err)
+	}
+	// 所属済みのユーザーを再度追加しても重複しない
+	again, _ := domain.NewMembership(organization.ID, 42)
+	if err := repo.AddMember(again); err != nil {
+		t.Fatalf("AddMember() again error = %v", err)
+	}
+
+	if member, _ := repo.IsMember(organization.ID, 42); !member {
+		t.Error("Expected user 42 to be a member")
+	}
+	organizations, _ := repo.FindByUserID(42)
+	found := false
+	for _, o := range organizations {
+		if o.ID == organization.ID {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("FindByUserID() did not return the organization")
+	}
+
+	if err := repo.RemoveMember(organization.ID, 42); err != nil {
+		t.Fatalf("RemoveMember() error = %v", err)
+	}
+	if member, _ := repo.IsMember(organization.ID, 42); member {
+		t.Error("Expected user 42 to no longer be a member")
+	}
+}
+
+func TestMemberRoleIsScopedToOrganization(t *testing.T) {
+	setupTestDatabase(t)
+
+	tenantA := createTestOrganization(t, "roles-a")
+	tenantB := createTestOrganization(t, "roles-b")
+	repo := NewOrganizationRepository()
+
+	for _, organization := range []*domain.Organization{tenantA, tenantB} {
+		membership, _ := domain.NewMembership(organization.ID, 43)
+		if err := repo.AddMember(membership); err != nil {
+			t.Fatalf("AddMember() error = %v", err)
+		}
+	}
+
+	membership, err := repo.FindMembership(tenantA.ID, 43)
+	if err != nil {
+		t.Fatalf("FindMembership() error = %v", err)
+	}
+	membership.SetRole(domain.RoleAdmin)
+	if err := repo.UpdateMemberRole(membership); err != nil {
+		t.Fatalf("UpdateMemberRole() error = %v", err)
+	}
+
+	if got, _ := repo.FindMembership(tenantA.ID, 43); got == nil || got.Role != domain.RoleAdmin {
+		t.Errorf("Expected admin in the organization that changed the role, got %+v", got)
+	}
+	if got, _ := repo.FindMembership(tenantB.ID, 43); got == nil || got.Role != domain.RoleMember {
+		t.Errorf("Expected the role in another organization to stay member, got %+v", got)
+	}
+
+	if _, err := repo.FindMembership(tenantB.ID, 44); err != domain.ErrNotOrganizationMember {
+		t.Errorf("Expected ErrNotOrganizationMember for a non-member, got %v", err)
+	}
+}
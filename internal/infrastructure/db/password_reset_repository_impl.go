@@ -0,0 +1,56 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type passwordResetRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPasswordResetRepository パスワード再設定トークンリポジトリを実装
+func NewPasswordResetRepository() repository.PasswordResetRepository {
+	return &passwordResetRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *passwordResetRepositoryImpl) Create(token *domain.PasswordResetToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByHash ハッシュからトークンを取得（見つからない場合は ErrInvalidResetToken）
+func (r *passwordResetRepositoryImpl) FindByHash(tokenHash string) (*domain.PasswordResetToken, error) {
+	var token domain.PasswordResetToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed used_at が NULL の行だけを更新する比較交換
+func (r *passwordResetRepositoryImpl) MarkUsed(token *domain.PasswordResetToken) (bool, error) {
+	result := r.db.Model(&domain.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", token.ID).
+		Update("used_at", token.UsedAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *passwordResetRepositoryImpl) InvalidateByUserID(userID uint, now time.Time) error {
+	return r.db.Model(&domain.PasswordResetToken{}).
+		Where("user_id = ? AND used_at IS NULL", userID).
+		Update("used_at", now).Error
+}
+
+func (r *passwordResetRepositoryImpl) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&domain.PasswordResetToken{}).Error
+}
@@ -0,0 +1,56 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestPasswordResetTokenSingleUse(t *testing.T) {
+	setupTestDatabase(t)
+
+	const userID = 4242
+	repo := NewPasswordResetRepository()
+	now := time.Now()
+	t.Cleanup(func() {
+		DB.Where("user_id = ?", userID).Delete(&domain.PasswordResetToken{})
+	})
+
+	first, _, _ := domain.NewPasswordResetToken(userID, now, time.Hour)
+	if err := repo.Create(first); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	// 新しいトークンを発行する前に以前のトークンを無効にする
+	if err := repo.InvalidateByUserID(userID, now); err != nil {
+		t.Fatalf("InvalidateByUserID() error = %v", err)
+	}
+	second, _, _ := domain.NewPasswordResetToken(userID, now, time.Hour)
+	if err := repo.Create(second); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	stored, _ := repo.FindByHash(first.TokenHash)
+	if err := stored.Use(now); err != domain.ErrInvalidResetToken {
+		t.Errorf("Expected the earlier token to be invalidated, got %v", err)
+	}
+
+	stored, _ = repo.FindByHash(second.TokenHash)
+	if err := stored.Use(now); err != nil {
+		t.Fatalf("Use() error = %v", err)
+	}
+	replica, _ := repo.FindByHash(second.TokenHash)
+	_ = replica.Use(now)
+
+	if used, err := repo.MarkUsed(stored); err != nil || !used {
+		t.Fatalf("MarkUsed() = %v, %v; want true", used, err)
+	}
+	if used, _ := repo.MarkUsed(replica); used {
+		t.Error("Expected MarkUsed() to fail for a token another request already used")
+	}
+
+	if _, err := repo.FindByHash(domain.HashToken("unknown")); err != domain.ErrInvalidResetToken {
+		t.Errorf("Expected ErrInvalidResetToken for an unknown token, got %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type refreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository リフレッシュトークンリポジトリを実装
+func NewRefreshTokenRepository() repository.RefreshTokenRepository {
+	return &refreshTokenRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *refreshTokenRepositoryImpl) Create(token *domain.RefreshToken) error {
+	return r.db.Create(token).Error
+}
+
+// FindByHash ハッシュからリフレッシュトークンを取得（見つからない場合は ErrInvalidRefreshToken）
+func (r *refreshTokenRepositoryImpl) FindByHash(tokenHash string) (*domain.RefreshToken, error) {
+	var token domain.RefreshToken
+	err := r.db.Where("token_hash = ?", tokenHash).First(&token).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+		return nil, err
+	}
+	return &token, nil
+}
+
+// MarkUsed used_at と revoked_at が NULL の行だけを更新する比較交換
+func (r *refreshTokenRepositoryImpl) MarkUsed(token *domain.RefreshToken) (bool, error) {
+	result := r.db.Model(&domain.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", token.ID).
+		Update("used_at", token.UsedAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+// RevokeFamily ファミリーの未失効のトークンをすべて失効させる
+func (r *refreshTokenRepositoryImpl) RevokeFamily(familyID string, now time.Time) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", now).Error
+}
+
+func (r *refreshTokenRepositoryImpl) FindWithLiveAccessToken(userID uint, now time.Time) ([]*domain.RefreshToken, error) {
+	var tokens []*domain.RefreshToken
+	err := r.db.Where("user_id = ? AND access_token_id <> '' AND access_token_expires_at > ?", userID, now).
+		Order("id").
+		Find(&tokens).Error
+	return tokens, err
+}
+
+// RevokeByUserID ユーザーの未失効のトークンをすべて失効させる
+func (r *refreshTokenRepositoryImpl) RevokeByUserID(userID uint, now time.Time) error {
+	return r.db.Model(&domain.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", now).Error
+}
+
+func (r *refreshTokenRepositoryImpl) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&domain.RefreshToken{}).Error
+}
@@ -0,0 +1,163 @@
+package db
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestRefreshTokenMarkUsedOnlyOnce(t *testing.T) {
+	setupTestDatabase(t)
+
+	repo := NewRefreshTokenRepository()
+	now := time.Now()
+	token, _, _ := domain.NewRefreshToken(1, 1, "", now, time.Hour)
+	if err := repo.Create(token); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("family_id = ?", token.FamilyID).Delete(&domain.RefreshToken{})
+	})
+
+	// 同じトークンで同時にリフレッシュしても使用済みにできるのは1回のみ
+	const attempts = 10
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		wins int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stored, err := repo.FindByHash(token.TokenHash)
+			if err != nil {
+				t.Errorf("FindByHash() error = %v", err)
+				return
+			}
+			if err := stored.Use(now); err != nil {
+				return
+			}
+			used, err := repo.MarkUsed(stored)
+			if err != nil {
+				t.Errorf("MarkUsed() error = %v", err)
+				return
+			}
+			if used {
+				mu.Lock()
+				wins++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if wins != 1 {
+		t.Errorf("Expected exactly one MarkUsed() to succeed, got %d", wins)
+	}
+}
+
+func TestRefreshTokenRevokeFamily(t *testing.T) {
+	setupTestDatabase(t)
+
+	repo := NewRefreshTokenRepository()
+	now := time.Now()
+	first, _, _ := domain.NewRefreshToken(1, 1, "", now, time.Hour)
+	second, _, _ := domain.NewRefreshToken(1, 1, first.FamilyID, now, time.Hour)
+	other, _, _ := domain.NewRefreshToken(1, 1, "", now, time.Hour)
+	for _, token := range []*domain.RefreshToken{first, second, other} {
+		if err := repo.Create(token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+	t.Cleanup(func() {
+		DB.Where("family_id IN ?", []string{first.FamilyID, other.FamilyID}).Delete(&domain.RefreshToken{})
+	})
+
+	if err := repo.RevokeFamily(first.FamilyID, now); err != nil {
+		t.Fatalf("RevokeFamily() error = %v", err)
+	}
+
+	for _, token := range []*domain.RefreshToken{first, second} {
+		stored, _ := repo.FindByHash(token.TokenHash)
+		if stored.RevokedAt == nil {
+			t.Errorf("Expected token %d in the family to be revoked", token.ID)
+		}
+	}
+	stored, _ := repo.FindByHash(other.TokenHash)
+	if stored.RevokedAt != nil {
+		t.Error("Expected a token from another family to stay active")
+	}
+
+	if _, err := repo.FindByHash(domain.HashToken("unknown")); err != domain.ErrInvalidRefreshToken {
+		t.Errorf("Expected ErrInvalidRefreshToken for an unknown token, got %v", err)
+	}
+}
+
+func TestRevokedTokens(t *testing.T) {
+	setupTestDatabase(t)
+
+	repo := NewRevokedTokenRepository()
+	token := &domain.RevokedToken{TokenID: "test-revoked-token", ExpiresAt: time.Now().Add(time.Hour)}
+	t.Cleanup(func() {
+		DB.Where("token_id = ?", token.TokenID).Delete(&domain.RevokedToken{})
+	})
+
+	if err := repo.Revoke(token); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+	// 同じトークンを再度失効させてもエラーにならない
+	if err := repo.Revoke(&domain.RevokedToken{TokenID: token.TokenID, ExpiresAt: token.ExpiresAt}); err != nil {
+		t.Fatalf("Revoke() again error = %v", err)
+	}
+
+	if revoked, _ := repo.IsRevoked(token.TokenID); !revoked {
+		t.Error("Expected the token to be revoked")
+	}
+	if revoked, _ := repo.IsRevoked("other-token"); revoked {
+		t.Error("Expected another token not to be revoked")
+	}
+}
+
+func TestRefreshTokenRevokeByUserID(t *testing.T) {
+	setupTestDatabase(t)
+
+	const userID = 4343
+	repo := NewRefreshTokenRepository()
+	now := time.Now()
+	t.Cleanup(func() {
+		DB.Where("user_id = ?", userID).Delete(&domain.RefreshToken{})
+	})
+
+	live, _, _ := domain.NewRefreshToken(userID, 1, "", now, time.Hour)
+	live.AccessTokenID = "live-access-token"
+	live.AccessTokenExpiresAt = now.Add(15 * time.Minute)
+	stale, _, _ := domain.NewRefreshToken(userID, 1, "", now, time.Hour)
+	stale.AccessTokenID = "stale-access-token"
+	stale.AccessTokenExpiresAt = now.Add(-time.Minute)
+	for _, token := range []*domain.RefreshToken{live, stale} {
+		if err := repo.Create(token); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	}
+
+	tokens, err := repo.FindWithLiveAccessToken(userID, now)
+	if err != nil {
+		t.Fatalf("FindWithLiveAccessToken() error = %v", err)
+	}
+	if len(tokens) != 1 || tokens[0].AccessTokenID != live.AccessTokenID {
+		t.Errorf("Expected only the token with a live access token, got %d tokens", len(tokens))
+	}
+
+	if err := repo.RevokeByUserID(userID, now); err != nil {
+		t.Fatalf("RevokeByUserID() error = %v", err)
+	}
+	for _, token := range []*domain.RefreshToken{live, stale} {
+		stored, _ := repo.FindByHash(token.TokenHash)
+		if stored.RevokedAt == nil {
+			t.Errorf("Expected token %d to be revoked", token.ID)
+		}
+	}
+}
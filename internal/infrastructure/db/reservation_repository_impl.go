@@ -1,10 +1,13 @@
 package db
 
 import (
+	"time"
+
 	"reservation-system/internal/domain"
 	"reservation-system/internal/repository"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type reservationRepositoryImpl struct {
@@ -22,9 +25,9 @@ func (r *reservationRepositoryImpl) Create(reservation *domain.Reservation) erro
 	return r.db.Create(reservation).Error
 }
 
-func (r *reservationRepositoryImpl) FindByID(id uint) (*domain.Reservation, error) {
+func (r *reservationRepositoryImpl) FindByID(organizationID, id uint) (*domain.Reservation, error) {
 	var reservation domain.Reservation
-	err := r.db.First(&reservation, id).Error
+	err := r.db.Scopes(inOrganization(organizationID)).First(&reservation, id).Error
 	if err != nil {
 		if err == gorm.ErrRecordNotFound {
 			return nil, domain.ErrReservationNotFound
@@ -34,9 +37,22 @@ func (r *reservationRepositoryImpl) FindByID(id uint) (*domain.Reservation, erro
 	return &reservation, nil
 }
 
-func (r *reservationRepositoryImpl) FindByUserID(userID uint) ([]*domain.Reservation, error) {
+func (r *reservationRepositoryImpl) FindByUserID(organizationID, userID uint) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Scopes(inOrganization(organizationID)).Where("user_id = ?", userID).Find(&reservations).Error
+	return reservations, err
+}
+
+// FindByResourceID リソースの予約を開始日時順に取得
+func (r *reservationRepositoryImpl) FindByResourceID(organizationID, resourceID uint) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Scopes(inOrganization(organizationID)).Where("resource_id = ?", resourceID).Order("start_at, id").Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepositoryImpl) FindBySeriesID(organizationID, seriesID uint) ([]*domain.Reservation, error) {
 	var reservations []*domain.Reservation
-	err := r.db.Where("user_id = ?", userID).Find(&reservations).Error
+	err := r.db.Scopes(inOrganization(organizationID)).Where("series_id = ?", seriesID).Order("start_at").Find(&reservations).Error
 	return reservations, err
 }
 
@@ -44,14 +60,176 @@ func (r *reservationRepositoryImpl) Update(reservation *domain.Reservation) erro
 	return r.db.Save(reservation).Error
 }
 
-func (r *reservationRepositoryImpl) Delete(id uint) error {
-	return r.db.Delete(&domain.Reservation{}, id).Error
+// UpdateAllIfStatus 複数の予約を1トランザクションで、それぞれ DB 上のステータスが expected[i] のままの場合のみ更新
+// 読み込んだ後にステータスが変わっていた予約は上書きせずに飛ばし、更新できた予約だけを返す
+func (r *reservationRepositoryImpl) UpdateAllIfStatus(reservations []*domain.Reservation, expected []domain.ReservationStatus) ([]*domain.Reservation, error) {
+	var updated []*domain.Reservation
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		for i, reservation := range reservations {
+			ok, err := updateIfStatus(tx, reservation, expected[i])
+			if err != nil {
+				return err
+			}
+			if ok {
+				updated = append(updated, reservation)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// UpdateIfStatus DB上のステータスが expected のままの場合のみ更新する
+// 複数レプリカのジョブが同じ予約を同時に処理しても、更新できるのは1つだけ
+func (r *reservationRepositoryImpl) UpdateIfStatus(reservation *domain.Reservation, expected domain.ReservationStatus) (bool, error) {
+	return updateIfStatus(r.db, reservation, expected)
+}
+
+// updateIfStatus ステータスが expected の行だけを対象に予約の全列を更新する比較交換
+func updateIfStatus(db *gorm.DB, reservation *domain.Reservation, expected domain.ReservationStatus) (bool, error) {
+	result := db.Model(&domain.Reservation{}).
+		Where("id = ? AND organization_id = ? AND status = ?", reservation.ID, reservation.OrganizationID, expected).
+		Select("*").
+		Omit("id", "created_at").
+		Updates(reservation)
+	return result.RowsAffected == 1, result.Error
 }
 
-func (r *reservationRepositoryImpl) CountByDateAndTime(date string, startTime, endTime string) (int, error) {
-	var count int64
-	err := r.db.Model(&domain.Reservation{}).
-		Where("DATE(created_at) = ? AND time_slot_start_time >= ? AND time_slot_end_time <= ?", date, startTime, endTime).
-		Count(&count).Error
-	return int(count), err
+// FindPendingCreatedBefore cutoff 以前に作成された仮予約を取得（承認待ちのものは除く）
+func (r *reservationRepositoryImpl) FindPendingCreatedBefore(cutoff time.Time) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Where("status = ? AND awaiting_approval = ? AND created_at <= ?", domain.StatusPending, false, cutoff).
+		Order("id").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindConfirmedStartedBefore 開始日時が cutoff 以前の確定済み予約を取得
+func (r *reservationRepositoryImpl) FindConfirmedStartedBefore(cutoff time.Time) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Where("status = ? AND start_at <= ?", domain.StatusConfirmed, cutoff).
+		Order("id").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// FindAwaitingApproval 組織内で approverID が承認者になっているリソースの承認待ち予約を開始日時順に取得
+func (r *reservationRepositoryImpl) FindAwaitingApproval(organizationID, approverID uint) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Scopes(inOrganization(organizationID)).
+		Where("resource_id IN (?)", r.db.Model(&domain.ResourceApprover{}).Select("resource_id").Where("user_id = ?", approverID)).
+		Where("status = ? AND awaiting_approval = ?", domain.StatusPending, true).
+		Order("start_at, id").
+		Find(&reservations).Error
+	return reservations, err
+}
+
+func (r *reservationRepositoryImpl) Delete(organizationID, id uint) error {
+	return r.db.Scopes(inOrganization(organizationID)).Delete(&domain.Reservation{}, id).Error
+}
+
+// CreateIfAvailable 定員に空きがある場合のみ予約を作成
+// リソース行を FOR UPDATE でロックし、同一リソースへの予約作成を直列化する
+func (r *reservationRepositoryImpl) CreateIfAvailable(reservation *domain.Reservation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		resource, err := lockResource(tx, reservation.OrganizationID, reservation.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		reserved, err := peakActiveQuantity(tx, resource, reservation.TimeSlot, 0)
+		if err != nil {
+			return err
+		}
+
+		if !reservation.TimeSlot.IsAvailable(reserved, reservation.Quantity) {
+			return domain.ErrCapacityExceeded
+		}
+
+		return tx.Create(reservation).Error
+	})
+}
+
+// UpdateIfAvailable 予約自身を除いて定員に空きがある場合のみ予約を更新
+// 満席の場合は DB 上の予約を変更せずに ErrCapacityExceeded を返す
+// 読み込んだ後にキャンセル・期限切れ・チェックインなどでステータスが変わっていた場合は ErrInvalidStatusTransition
+func (r *reservationRepositoryImpl) UpdateIfAvailable(reservation *domain.Reservation) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		resource, err := lockResource(tx, reservation.OrganizationID, reservation.ResourceID)
+		if err != nil {
+			return err
+		}
+
+		reserved, err := peakActiveQuantity(tx, resource, reservation.TimeSlot, reservation.ID)
+		if err != nil {
+			return err
+		}
+
+		if !reservation.TimeSlot.IsAvailable(reserved, reservation.Quantity) {
+			return domain.ErrCapacityExceeded
+		}
+
+		// 予約の変更ではステータスを変えないため、読み込んだときのステータスのままの場合のみ更新する
+		updated, err := updateIfStatus(tx, reservation, reservation.Status)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return domain.ErrInvalidStatusTransition
+		}
+		return nil
+	})
+}
+
+// FindActiveByResourceAndPeriod startAt から endAt までの期間に一部でも重なる有効な予約を一括取得
+func (r *reservationRepositoryImpl) FindActiveByResourceAndPeriod(organizationID, resourceID uint, startAt, endAt time.Time) ([]*domain.Reservation, error) {
+	var reservations []*domain.Reservation
+	err := r.db.Scopes(inOrganization(organizationID)).
+		Where("resource_id = ? AND status IN ?", resourceID, domain.ActiveStatuses).
+		Where("start_at < ? AND end_at > ?", endAt, startAt).
+		Find(&reservations).Error
+	return reservations, err
+}
+
+// lockResource トランザクション終了までリソース行を排他ロックし、重なり判定と予約作成に必要な列を返す
+// 組織の異なるリソースは ErrResourceNotFound として扱い、他の組織のリソースへの予約を防ぐ
+func lockResource(tx *gorm.DB, organizationID, resourceID uint) (*domain.Resource, error) {
+	var resource domain.Resource
+	err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id", "organization_id", "setup_buffer_minutes", "teardown_buffer_minutes", "requires_approval").
+		Scopes(inOrganization(organizationID)).
+		First(&resource, resourceID).Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, domain.ErrResourceNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
+
+// peakActiveQuantity リソースのバッファを含めて時間帯が重なる有効な予約から、時間枠内で同時に使われる席数の最大値を取得
+// 長い時間枠では互いに重ならない予約の席数を合算しないよう、単純な合計ではなく同時使用数で判定する
+// excludeID の予約は集計から除く（0 の場合は除外なし）
+func peakActiveQuantity(tx *gorm.DB, resource *domain.Resource, timeSlot *domain.TimeSlot, excludeID uint) (int, error) {
+	var reservations []*domain.Reservation
+	err := tx.Select("id", "resource_id", "status", "quantity", "start_at", "end_at").
+		Where("resource_id = ? AND status IN ? AND id <> ?", resource.ID, domain.ActiveStatuses, excludeID).
+		Scopes(inOrganization(resource.OrganizationID)).
+		Scopes(overlapping(resource.BlockingWindow(timeSlot))).
+		Find(&reservations).Error
+	if err != nil {
+		return 0, err
+	}
+	return resource.PeakBooked(timeSlot, reservations), nil
+}
+
+// overlapping 埋め込み TimeSlot を持つテーブルを、絶対時刻で時間帯が重なる行に絞り込む
+func overlapping(timeSlot *domain.TimeSlot) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		return db.Where("start_at < ? AND end_at > ?", timeSlot.EndAt, timeSlot.StartAt)
+	}
 }
@@ -0,0 +1,448 @@
+package db
+
+import (
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// setupTestDatabase DB_HOST が設定されていない環境ではスキップする
+func setupTestDatabase(t *testing.T) {
+	t.Helper()
+	if os.Getenv("DB_HOST") == "" {
+		t.Skip("DB_HOST is not set; skipping database integration test")
+	}
+	if DB == nil {
+		if err := InitDatabase(); err != nil {
+			t.Fatalf("InitDatabase() error = %v", err)
+		}
+	}
+}
+
+func TestCreateIfAvailableConcurrentBookings(t *testing.T) {
+	setupTestDatabase(t)
+
+	const capacity = 3
+	const attempts = 20
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Concurrency Room", domain.ResourceTypeMeetingRoom, capacity, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	repo := NewReservationRepository()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		created  int
+		rejected int
+	)
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(userID uint) {
+			defer wg.Done()
+			ts, _ := domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+			reservation, _ := domain.NewReservation(userID, resource.ID, ts, 1)
+
+			err := repo.CreateIfAvailable(reservation)
+
+			mu.Lock()
+			defer mu.Unlock()
+			switch err {
+			case nil:
+				created++
+			case domain.ErrCapacityExceeded:
+				rejected++
+			default:
+				t.Errorf("CreateIfAvailable() unexpected error = %v", err)
+			}
+		}(uint(i + 1))
+	}
+	wg.Wait()
+
+	if created != capacity {
+		t.Errorf("Expected %d reservations to be created, got %d", capacity, created)
+	}
+	if rejected != attempts-capacity {
+		t.Errorf("Expected %d reservations to be rejected, got %d", attempts-capacity, rejected)
+	}
+}
+
+func TestCreateIfAvailableIgnoresCancelledAndOtherSlots(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Counting Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	// キャンセル済みの予約は定員を消費しない
+	ts, _ := domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+	cancelled, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	cancelled.Status = domain.StatusCancelled
+	if err := repo.Create(cancelled); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	ts, _ = domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+	first, _ := domain.NewReservation(2, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(first); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 同じ時間帯の別の日は別枠
+	ts, _ = domain.NewTimeSlot(date.AddDate(0, 0, 7), "09:00", "10:00", resource.Capacity)
+	nextWeek, _ := domain.NewReservation(3, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(nextWeek); err != nil {
+		t.Errorf("CreateIfAvailable() on another date error = %v", err)
+	}
+
+	// 重なる時間帯は満席
+	ts, _ = domain.NewTimeSlot(date, "09:30", "10:30", resource.Capacity)
+	overlapping, _ := domain.NewReservation(4, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(overlapping); err != domain.ErrCapacityExceeded {
+		t.Errorf("Expected ErrCapacityExceeded for overlapping slot, got %v", err)
+	}
+}
+
+func TestUpdateIfStatusOnlyOneReplicaWins(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Expiry Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	ts, _ := domain.NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", resource.Capacity)
+	reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(reservation); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 2つのレプリカが同じ仮予約を読み込んだ状態を再現する
+	replicaA, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	replicaB, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	now := replicaA.CreatedAt.Add(time.Hour)
+
+	_ = replicaA.Expire(now, time.Minute)
+	_ = replicaB.Expire(now, time.Minute)
+
+	updatedA, err := repo.UpdateIfStatus(replicaA, domain.StatusPending)
+	if err != nil || !updatedA {
+		t.Fatalf("First UpdateIfStatus() = %v, %v; want true, nil", updatedA, err)
+	}
+	updatedB, err := repo.UpdateIfStatus(replicaB, domain.StatusPending)
+	if err != nil || updatedB {
+		t.Errorf("Second UpdateIfStatus() = %v, %v; want false, nil", updatedB, err)
+	}
+
+	stored, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	if stored.Status != domain.StatusExpired {
+		t.Errorf("Expected stored status %s, got %s", domain.StatusExpired, stored.Status)
+	}
+}
+
+func TestConfirmLosesToExpirySweep(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Confirm Race Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	ts, _ := domain.NewTimeSlot(time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC), "09:00", "10:00", resource.Capacity)
+	reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(reservation); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 利用者の確定とスイープが同じ仮予約を読み込んだ状態を再現する
+	confirming, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	sweeping, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	now := sweeping.CreatedAt.Add(time.Hour)
+
+	if err := sweeping.Expire(now, time.Minute); err != nil {
+		t.Fatalf("Expire() error = %v", err)
+	}
+	if err := confirming.Confirm(now); err != nil {
+		t.Fatalf("Confirm() error = %v", err)
+	}
+
+	if updated, err := repo.UpdateIfStatus(sweeping, domain.StatusPending); err != nil || !updated {
+		t.Fatalf("Sweep UpdateIfStatus() = %v, %v; want true, nil", updated, err)
+	}
+	if updated, err := repo.UpdateIfStatus(confirming, domain.StatusPending); err != nil || updated {
+		t.Errorf("Confirm UpdateIfStatus() = %v, %v; want false, nil", updated, err)
+	}
+
+	stored, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	if stored.Status != domain.StatusExpired {
+		t.Errorf("Expected the expired reservation not to be confirmed, got %s", stored.Status)
+	}
+}
+
+func TestUpdateAllIfStatusSkipsChangedReservations(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Series Cancel Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	var loaded []*domain.Reservation
+	for _, slot := range [][2]string{{"09:00", "10:00"}, {"11:00", "12:00"}} {
+		ts, _ := domain.NewTimeSlot(date, slot[0], slot[1], resource.Capacity)
+		reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+		if err := repo.CreateIfAvailable(reservation); err != nil {
+			t.Fatalf("CreateIfAvailable() error = %v", err)
+		}
+		stored, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+		loaded = append(loaded, stored)
+	}
+
+	// シリーズのキャンセルが読み込んだ後に、2回目の予約が期限切れになった状態を再現する
+	expiring, _ := repo.FindByID(loaded[1].OrganizationID, loaded[1].ID)
+	_ = expiring.Expire(expiring.CreatedAt.Add(time.Hour), time.Minute)
+	if updated, err := repo.UpdateIfStatus(expiring, domain.StatusPending); err != nil || !updated {
+		t.Fatalf("UpdateIfStatus() = %v, %v; want true, nil", updated, err)
+	}
+
+	expected := make([]domain.ReservationStatus, len(loaded))
+	for i, reservation := range loaded {
+		expected[i] = reservation.Status
+		if err := reservation.Cancel(date.Add(-24*time.Hour), nil); err != nil {
+			t.Fatalf("Cancel() error = %v", err)
+		}
+	}
+
+	updated, err := repo.UpdateAllIfStatus(loaded, expected)
+	if err != nil {
+		t.Fatalf("UpdateAllIfStatus() error = %v", err)
+	}
+	if len(updated) != 1 || updated[0].ID != loaded[0].ID {
+		t.Errorf("Expected only the first reservation to be cancelled, got %d", len(updated))
+	}
+
+	stored, _ := repo.FindByID(loaded[1].OrganizationID, loaded[1].ID)
+	if stored.Status != domain.StatusExpired {
+		t.Errorf("Expected the expired reservation not to be overwritten, got %s", stored.Status)
+	}
+}
+
+func TestUpdateIfAvailableKeepsOriginalWhenTargetIsFull(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Reschedule Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+
+	ts, _ := domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+	reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(reservation); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	ts, _ = domain.NewTimeSlot(date, "11:00", "12:00", resource.Capacity)
+	other, _ := domain.NewReservation(2, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(other); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 自分自身の予約とだけ重なる時間枠へは移動できる
+	ts, _ = domain.NewTimeSlot(date, "09:30", "10:30", resource.Capacity)
+	_ = reservation.Reschedule(ts)
+	if err := repo.UpdateIfAvailable(reservation); err != nil {
+		t.Fatalf("UpdateIfAvailable() error = %v", err)
+	}
+
+	// 満席の時間枠へは移動できず、DB 上の予約は変わらない
+	ts, _ = domain.NewTimeSlot(date, "11:00", "12:00", resource.Capacity)
+	_ = reservation.Reschedule(ts)
+	if err := repo.UpdateIfAvailable(reservation); err != domain.ErrCapacityExceeded {
+		t.Errorf("Expected ErrCapacityExceeded, got %v", err)
+	}
+
+	stored, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	if stored.TimeSlot.StartTime != "09:30" || stored.TimeSlot.EndTime != "10:30" {
+		t.Errorf("Expected stored slot 09:30-10:30, got %s-%s", stored.TimeSlot.StartTime, stored.TimeSlot.EndTime)
+	}
+}
+
+func TestUpdateIfAvailableRejectsReservationCancelledMeanwhile(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Reschedule Race Room", domain.ResourceTypeMeetingRoom, 1, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	ts, _ := domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+	reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+	if err := repo.CreateIfAvailable(reservation); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 予約の変更とキャンセルが同じ予約を読み込んだ状態を再現する
+	rescheduling, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	cancelling, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+
+	if err := cancelling.Cancel(date.Add(-24*time.Hour), nil); err != nil {
+		t.Fatalf("Cancel() error = %v", err)
+	}
+	if updated, err := repo.UpdateIfStatus(cancelling, domain.StatusPending); err != nil || !updated {
+		t.Fatalf("UpdateIfStatus() = %v, %v; want true, nil", updated, err)
+	}
+
+	ts, _ = domain.NewTimeSlot(date, "11:00", "12:00", resource.Capacity)
+	if err := rescheduling.Reschedule(ts); err != nil {
+		t.Fatalf("Reschedule() error = %v", err)
+	}
+	if err := repo.UpdateIfAvailable(rescheduling); err != domain.ErrInvalidStatusTransition {
+		t.Errorf("Expected ErrInvalidStatusTransition, got %v", err)
+	}
+
+	stored, _ := repo.FindByID(reservation.OrganizationID, reservation.ID)
+	if stored.Status != domain.StatusCancelled || stored.TimeSlot.StartTime != "09:00" {
+		t.Errorf("Expected the cancelled 09:00 reservation to stay as it was, got %s at %s", stored.Status, stored.TimeSlot.StartTime)
+	}
+}
+
+func TestFindConfirmedStartedBefore(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("No-show Room", domain.ResourceTypeMeetingRoom, 5, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	create := func(day time.Time, start, end string, confirm bool) *domain.Reservation {
+		ts, _ := domain.NewTimeSlot(day, start, end, resource.Capacity)
+		reservation, _ := domain.NewReservation(1, resource.ID, ts, 1)
+		if confirm {
+			_ = reservation.Confirm(day)
+		}
+		if err := repo.Create(reservation); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		return reservation
+	}
+
+	yesterday := create(date.AddDate(0, 0, -1), "15:00", "16:00", true)
+	started := create(date, "09:00", "10:00", true)
+	create(date, "09:00", "10:00", false)
+	create(date, "11:00", "12:00", true)
+
+	found, err := repo.FindConfirmedStartedBefore(time.Date(2026, 1, 12, 9, 15, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("FindConfirmedStartedBefore() error = %v", err)
+	}
+
+	var ids []uint
+	for _, reservation := range found {
+		if reservation.ResourceID == resource.ID {
+			ids = append(ids, reservation.ID)
+		}
+	}
+	if len(ids) != 2 || ids[0] != yesterday.ID || ids[1] != started.ID {
+		t.Errorf("Expected reservations %d and %d, got %v", yesterday.ID, started.ID, ids)
+	}
+}
+
+func TestCreateIfAvailableMultiDayRental(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Rental Camera", domain.ResourceTypeDevice, 2, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	repo := NewReservationRepository()
+	monday := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	book := func(userID uint, startDay, endDay int) error {
+		ts, _ := domain.NewLocalTimeSpan(time.UTC, monday.AddDate(0, 0, startDay), "09:00", monday.AddDate(0, 0, endDay), "18:00", resource.Capacity)
+		reservation, _ := domain.NewReservation(userID, resource.ID, ts, 1)
+		return repo.CreateIfAvailable(reservation)
+	}
+
+	if err := book(1, 0, 0); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+	if err := book(2, 2, 2); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 月曜と水曜の予約は重ならないため、3日間の貸出には1台空いている
+	if err := book(3, 0, 2); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	// 月曜は2台とも使われている
+	if err := book(4, 0, 1); err != domain.ErrCapacityExceeded {
+		t.Errorf("Expected ErrCapacityExceeded, got %v", err)
+	}
+}
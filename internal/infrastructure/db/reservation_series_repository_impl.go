@@ -0,0 +1,35 @@
+package db
+
+import (
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type reservationSeriesRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewReservationSeriesRepository 繰り返し予約リポジトリを実装
+func NewReservationSeriesRepository() repository.ReservationSeriesRepository {
+	return &reservationSeriesRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *reservationSeriesRepositoryImpl) Create(series *domain.ReservationSeries) error {
+	return r.db.Create(series).Error
+}
+
+func (r *reservationSeriesRepositoryImpl) FindByID(organizationID, id uint) (*domain.ReservationSeries, error) {
+	var series domain.ReservationSeries
+	err := r.db.Scopes(inOrganization(organizationID)).First(&series, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrSeriesNotFound
+		}
+		return nil, err
+	}
+	return &series, nil
+}
@@ -0,0 +1,142 @@
+package db
+
+import (
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+)
+
+type resourceRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewResourceRepository リソースリポジトリを実装
+func NewResourceRepository() repository.ResourceRepository {
+	return &resourceRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *resourceRepositoryImpl) Create(resource *domain.Resource) error {
+	return r.db.Create(resource).Error
+}
+
+func (r *resourceRepositoryImpl) FindByID(organizationID, id uint) (*domain.Resource, error) {
+	var resource domain.Resource
+	err := r.db.Scopes(inOrganization(organizationID)).Preload("OpeningHours").Preload("CancellationPolicy").Preload("Approvers").Preload("Staff").First(&resource, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrResourceNotFound
+		}
+		return nil, err
+	}
+	return &resource, nil
+}
+
+func (r *resourceRepositoryImpl) FindAll(organizationID uint) ([]*domain.Resource, error) {
+	var resources []*domain.Resource
+	err := r.db.Scopes(inOrganization(organizationID)).Preload("OpeningHours").Preload("CancellationPolicy").Preload("Approvers").Preload("Staff").Order("id").Find(&resources).Error
+	return resources, err
+}
+
+func (r *resourceRepositoryImpl) Update(resource *domain.Resource) error {
+	return r.db.Omit("OpeningHours", "CancellationPolicy", "Approvers", "Staff").Save(resource).Error
+}
+
+// ReplaceSchedule 営業時間を全件入れ替え
+func (r *resourceRepositoryImpl) ReplaceSchedule(resource *domain.Resource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", resource.ID).Delete(&domain.OpeningHours{}).Error; err != nil {
+			return err
+		}
+		if len(resource.OpeningHours) == 0 {
+			return nil
+		}
+		return tx.Create(&resource.OpeningHours).Error
+	})
+}
+
+// ReplaceCancellationPolicy キャンセル料段階を全件入れ替え
+func (r *resourceRepositoryImpl) ReplaceCancellationPolicy(resource *domain.Resource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", resource.ID).Delete(&domain.CancellationTier{}).Error; err != nil {
+			return err
+		}
+		if len(resource.CancellationPolicy) == 0 {
+			return nil
+		}
+		return tx.Create(&resource.CancellationPolicy).Error
+	})
+}
+
+// ReplaceApprovers 承認の要否を更新し、承認者を全件入れ替え
+func (r *resourceRepositoryImpl) ReplaceApprovers(resource *domain.Resource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Model(&domain.Resource{}).Where("id = ?", resource.ID).
+			Update("requires_approval", resource.RequiresApproval).Error
+		if err != nil {
+			return err
+		}
+		if err := tx.Where("resource_id = ?", resource.ID).Delete(&domain.ResourceApprover{}).Error; err != nil {
+			return err
+		}
+		if len(resource.Approvers) == 0 {
+			return nil
+		}
+		return tx.Create(&resource.Approvers).Error
+	})
+}
+
+// ReplaceStaff 担当スタッフを全件入れ替え
+func (r *resourceRepositoryImpl) ReplaceStaff(resource *domain.Resource) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("resource_id = ?", resource.ID).Delete(&domain.ResourceStaff{}).Error; err != nil {
+			return err
+		}
+		if len(resource.Staff) == 0 {
+			return nil
+		}
+		return tx.Create(&resource.Staff).Error
+	})
+}
+
+// FindIDsByStaff 組織内で指定ユーザーが担当スタッフになっているリソースのIDを取得
+func (r *resourceRepositoryImpl) FindIDsByStaff(organizationID, userID uint) ([]uint, error) {
+	var ids []uint
+	err := r.db.Model(&domain.ResourceStaff{}).
+		Where("user_id = ?", userID).
+		Where("resource_id IN (?)", r.db.Model(&domain.Resource{}).Select("id").Scopes(inOrganization(organizationID))).
+		Order("resource_id").
+		Pluck("resource_id", &ids).Error
+	return ids, err
+}
+
+// Delete 組織のリソースを関連データごと削除（他の組織のリソースは ErrResourceNotFound）
+func (r *resourceRepositoryImpl) Delete(organizationID, id uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&domain.Resource{}).Scopes(inOrganization(organizationID)).Where("id = ?", id).Count(&count).Error; err != nil {
+			return err
+		}
+		if count == 0 {
+			return domain.ErrResourceNotFound
+		}
+		if err := tx.Where("resource_id = ?", id).Delete(&domain.OpeningHours{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_id = ?", id).Delete(&domain.CancellationTier{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_id = ?", id).Delete(&domain.BlackoutDate{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_id = ?", id).Delete(&domain.ResourceApprover{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("resource_id = ?", id).Delete(&domain.ResourceStaff{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&domain.Resource{}, id).Error
+	})
+}
@@ -0,0 +1,37 @@
+package db
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type revokedTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRevokedTokenRepository アクセストークン失効リストのリポジトリを実装
+func NewRevokedTokenRepository() repository.RevokedTokenRepository {
+	return &revokedTokenRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+// Revoke 失効リストに追加（登録済みの場合は何もしない）
+func (r *revokedTokenRepositoryImpl) Revoke(token *domain.RevokedToken) error {
+	return r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *revokedTokenRepositoryImpl) IsRevoked(tokenID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&domain.RevokedToken{}).Where("token_id = ?", tokenID).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *revokedTokenRepositoryImpl) DeleteExpiredBefore(cutoff time.Time) error {
+	return r.db.Where("expires_at < ?", cutoff).Delete(&domain.RevokedToken{}).Error
+}
@@ -54,6 +54,49 @@ func (r *userRepositoryImpl) Delete(id uint) error {
 	return r.db.Delete(&domain.User{}, id).Error
 }
 
+func (r *userRepositoryImpl) IncrementFailedAttempts(id uint) (int, error) {
+	var attempts int
+	err := r.db.Raw(`UPDATE users SET failed_attempts = failed_attempts + 1 WHERE id = ? RETURNING failed_attempts`, id).
+		Scan(&attempts).Error
+	return attempts, err
+}
+
+func (r *userRepositoryImpl) MarkEmailVerified(user *domain.User) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND email_verified_at IS NULL", user.ID).
+		Update("email_verified_at", user.EmailVerifiedAt)
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *userRepositoryImpl) UpdatePassword(user *domain.User) error {
+	return r.db.Model(user).Select("password").Updates(user).Error
+}
+
+func (r *userRepositoryImpl) UpdateLockout(user *domain.User) error {
+	return r.db.Model(user).Select("failed_attempts", "locked_until").Updates(user).Error
+}
+
+func (r *userRepositoryImpl) UpdateTOTP(user *domain.User) error {
+	return r.db.Model(user).Select("totp_secret", "totp_enabled_at", "totp_last_used_step").Updates(user).Error
+}
+
+func (r *userRepositoryImpl) EnableTOTP(user *domain.User) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND totp_enabled_at IS NULL AND totp_secret = ? AND totp_last_used_step < ?", user.ID, user.TOTPSecret, user.TOTPLastUsedStep).
+		Updates(map[string]interface{}{
+			"totp_enabled_at":     user.TOTPEnabledAt,
+			"totp_last_used_step": user.TOTPLastUsedStep,
+		})
+	return result.RowsAffected == 1, result.Error
+}
+
+func (r *userRepositoryImpl) UseTOTPStep(user *domain.User) (bool, error) {
+	result := r.db.Model(&domain.User{}).
+		Where("id = ? AND totp_enabled_at IS NOT NULL AND totp_last_used_step < ?", user.ID, user.TOTPLastUsedStep).
+		Update("totp_last_used_step", user.TOTPLastUsedStep)
+	return result.RowsAffected == 1, result.Error
+}
+
 func (r *userRepositoryImpl) Exists(email string) (bool, error) {
 	var count int64
 	err := r.db.Model(&domain.User{}).Where("email = ?", email).Count(&count).Error
@@ -0,0 +1,131 @@
+package db
+
+import (
+	"reservation-system/internal/domain"
+	"reservation-system/internal/repository"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type waitlistRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewWaitlistRepository キャンセル待ちリポジトリを実装
+func NewWaitlistRepository() repository.WaitlistRepository {
+	return &waitlistRepositoryImpl{
+		db: GetDB(),
+	}
+}
+
+func (r *waitlistRepositoryImpl) Create(entry *domain.WaitlistEntry) error {
+	return r.db.Create(entry).Error
+}
+
+func (r *waitlistRepositoryImpl) FindByID(organizationID, id uint) (*domain.WaitlistEntry, error) {
+	var entry domain.WaitlistEntry
+	err := r.db.Scopes(inOrganization(organizationID)).First(&entry, id).Error
+	if err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, domain.ErrWaitlistEntryNotFound
+		}
+		return nil, err
+	}
+	return &entry, nil
+}
+
+func (r *waitlistRepositoryImpl) UpdateIfWaiting(entry *domain.WaitlistEntry) (bool, error) {
+	result := r.db.Model(&domain.WaitlistEntry{}).
+		Where("id = ? AND organization_id = ? AND status = ?", entry.ID, entry.OrganizationID, domain.WaitlistStatusWaiting).
+		Update("status", entry.Status)
+	return result.RowsAffected == 1, result.Error
+}
+
+// Position 同じ時間枠を待っている中での順番（1始まり）
+func (r *waitlistRepositoryImpl) Position(entry *domain.WaitlistEntry) (int, error) {
+	var ahead int64
+	err := r.db.Model(&domain.WaitlistEntry{}).
+		Where("resource_id = ? AND status = ? AND id < ?", entry.ResourceID, domain.WaitlistStatusWaiting, entry.ID).
+		Scopes(inOrganization(entry.OrganizationID)).
+		Scopes(overlapping(entry.TimeSlot)).
+		Count(&ahead).Error
+	return int(ahead) + 1, err
+}
+
+// PromoteWaiting 空いた時間枠に重なるキャンセル待ちを登録順に仮予約へ繰り上げ
+// 予約作成と同じくリソース行をロックするため、複数レプリカから同時に呼ばれても二重に繰り上げない
+// 登録順は時間枠ごとに守り、席数が確保できない登録者と時間枠が重なる後続は追い越させない
+// 重ならない時間枠のキャンセル待ちは、その枠に空きがあれば繰り上げる
+func (r *waitlistRepositoryImpl) PromoteWaiting(organizationID, resourceID uint, timeSlot *domain.TimeSlot) ([]*domain.WaitlistEntry, error) {
+	var promoted []*domain.WaitlistEntry
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		resource, err := lockResource(tx, organizationID, resourceID)
+		if err != nil {
+			return err
+		}
+
+		// バッファ分だけ離れたキャンセル待ちも空いた予約の影響を受ける
+		// 繰り上げる間に取り下げられないよう、対象の行もロックする
+		var entries []*domain.WaitlistEntry
+		err = tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("resource_id = ? AND status = ?", resourceID, domain.WaitlistStatusWaiting).
+			Scopes(inOrganization(organizationID)).
+			Scopes(overlapping(resource.BlockingWindow(timeSlot))).
+			Order("id").
+			Find(&entries).Error
+		if err != nil {
+			return err
+		}
+
+		var blocked []*domain.TimeSlot
+		for _, entry := range entries {
+			if overlapsAny(entry.TimeSlot, blocked) {
+				continue
+			}
+
+			reserved, err := peakActiveQuantity(tx, resource, entry.TimeSlot, 0)
+			if err != nil {
+				return err
+			}
+			if !entry.TimeSlot.IsAvailable(reserved, entry.Quantity) {
+				blocked = append(blocked, entry.TimeSlot)
+				continue
+			}
+
+			reservation, err := entry.NewReservation()
+			if err != nil {
+				return err
+			}
+			if resource.RequiresApproval {
+				reservation.RequireApproval()
+			}
+			if err := tx.Create(reservation).Error; err != nil {
+				return err
+			}
+
+			if err := entry.Promote(reservation.ID); err != nil {
+				return err
+			}
+			if err := tx.Save(entry).Error; err != nil {
+				return err
+			}
+			promoted = append(promoted, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+// overlapsAny 時間枠がいずれかの時間枠と重なるかチェック
+func overlapsAny(timeSlot *domain.TimeSlot, others []*domain.TimeSlot) bool {
+	for _, other := range others {
+		if timeSlot.Overlaps(other) {
+			return true
+		}
+	}
+	return false
+}
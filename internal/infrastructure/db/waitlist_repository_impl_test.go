@@ -0,0 +1,151 @@
+package db
+
+import (
+	"testing"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+func TestPromoteWaitingIsFIFO(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Waitlist Room", domain.ResourceTypeMeetingRoom, 2, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.WaitlistEntry{})
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	reservationRepo := NewReservationRepository()
+	waitlistRepo := NewWaitlistRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	newSlot := func() *domain.TimeSlot {
+		ts, _ := domain.NewTimeSlot(date, "09:00", "10:00", resource.Capacity)
+		return ts
+	}
+
+	booked, _ := domain.NewReservation(1, resource.ID, newSlot(), 2)
+	if err := reservationRepo.CreateIfAvailable(booked); err != nil {
+		t.Fatalf("CreateIfAvailable() error = %v", err)
+	}
+
+	first, _ := domain.NewWaitlistEntry(2, resource.ID, newSlot(), 1)
+	second, _ := domain.NewWaitlistEntry(3, resource.ID, newSlot(), 1)
+	third, _ := domain.NewWaitlistEntry(4, resource.ID, newSlot(), 1)
+	for _, entry := range []*domain.WaitlistEntry{first, second, third} {
+		if err := waitlistRepo.Create(entry); err != nil {
+			t.Fatalf("Create waitlist entry error = %v", err)
+		}
+	}
+
+	if position, _ := waitlistRepo.Position(third); position != 3 {
+		t.Errorf("Expected third entry at position 3, got %d", position)
+	}
+
+	// 満席の間は繰り上げない
+	promoted, err := waitlistRepo.PromoteWaiting(resource.OrganizationID, resource.ID, newSlot())
+	if err != nil {
+		t.Fatalf("PromoteWaiting() error = %v", err)
+	}
+	if len(promoted) != 0 {
+		t.Errorf("Expected no promotion while full, got %d", len(promoted))
+	}
+
+	_ = booked.Cancel(date.AddDate(0, 0, -1), nil)
+	if err := reservationRepo.Update(booked); err != nil {
+		t.Fatalf("Update() error = %v", err)
+	}
+
+	promoted, err = waitlistRepo.PromoteWaiting(resource.OrganizationID, resource.ID, newSlot())
+	if err != nil {
+		t.Fatalf("PromoteWaiting() error = %v", err)
+	}
+	if len(promoted) != 2 || promoted[0].ID != first.ID || promoted[1].ID != second.ID {
+		t.Fatalf("Expected first two entries to be promoted in order, got %+v", promoted)
+	}
+
+	if position, _ := waitlistRepo.Position(third); position != 1 {
+		t.Errorf("Expected third entry to move to position 1, got %d", position)
+	}
+
+	// 読み込んだ後に繰り上げられた登録は取り下げられない
+	stale, _ := domain.NewWaitlistEntry(2, resource.ID, newSlot(), 1)
+	stale.ID, stale.OrganizationID = first.ID, first.OrganizationID
+	_ = stale.Leave()
+	if left, err := waitlistRepo.UpdateIfWaiting(stale); err != nil || left {
+		t.Errorf("UpdateIfWaiting() on a promoted entry = %v, %v; want false, nil", left, err)
+	}
+	if stored, _ := waitlistRepo.FindByID(first.OrganizationID, first.ID); stored.Status != domain.WaitlistStatusPromoted {
+		t.Errorf("Expected the promoted entry to stay promoted, got %s", stored.Status)
+	}
+
+	_ = third.Leave()
+	if left, err := waitlistRepo.UpdateIfWaiting(third); err != nil || !left {
+		t.Errorf("UpdateIfWaiting() on a waiting entry = %v, %v; want true, nil", left, err)
+	}
+}
+
+func TestPromoteWaitingIsFIFOPerSlot(t *testing.T) {
+	setupTestDatabase(t)
+
+	resourceRepo := NewResourceRepository()
+	resource, _ := domain.NewResource("Waitlist Slots Room", domain.ResourceTypeMeetingRoom, 2, "")
+	if err := resourceRepo.Create(resource); err != nil {
+		t.Fatalf("Create resource error = %v", err)
+	}
+	t.Cleanup(func() {
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.WaitlistEntry{})
+		DB.Where("resource_id = ?", resource.ID).Delete(&domain.Reservation{})
+		resourceRepo.Delete(resource.OrganizationID, resource.ID)
+	})
+
+	reservationRepo := NewReservationRepository()
+	waitlistRepo := NewWaitlistRepository()
+	date := time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC)
+	newSlot := func(start, end string) *domain.TimeSlot {
+		ts, _ := domain.NewTimeSlot(date, start, end, resource.Capacity)
+		return ts
+	}
+
+	long, _ := domain.NewReservation(1, resource.ID, newSlot("09:00", "11:00"), 1)
+	short, _ := domain.NewReservation(2, resource.ID, newSlot("09:00", "10:00"), 1)
+	for _, reservation := range []*domain.Reservation{long, short} {
+		if err := reservationRepo.CreateIfAvailable(reservation); err != nil {
+			t.Fatalf("CreateIfAvailable() error = %v", err)
+		}
+	}
+
+	// 09:00-10:00 は2席必要な登録者が先頭、10:00-11:00 は別の時間枠
+	large, _ := domain.NewWaitlistEntry(3, resource.ID, newSlot("09:00", "10:00"), 2)
+	other, _ := domain.NewWaitlistEntry(4, resource.ID, newSlot("10:00", "11:00"), 2)
+	behind, _ := domain.NewWaitlistEntry(5, resource.ID, newSlot("09:00", "10:00"), 1)
+	for _, entry := range []*domain.WaitlistEntry{large, other, behind} {
+		if err := waitlistRepo.Create(entry); err != nil {
+			t.Fatalf("Create waitlist entry error = %v", err)
+		}
+	}
+
+	_ = long.Cancel(date.AddDate(0, 0, -1), nil)
+	if updated, err := reservationRepo.UpdateIfStatus(long, domain.StatusPending); err != nil || !updated {
+		t.Fatalf("UpdateIfStatus() = %v, %v; want true, nil", updated, err)
+	}
+
+	promoted, err := waitlistRepo.PromoteWaiting(resource.OrganizationID, resource.ID, newSlot("09:00", "11:00"))
+	if err != nil {
+		t.Fatalf("PromoteWaiting() error = %v", err)
+	}
+	if len(promoted) != 1 || promoted[0].ID != other.ID {
+		t.Fatalf("Expected only the entry for the other slot to be promoted, got %+v", promoted)
+	}
+
+	// 1席空いた 09:00-10:00 でも、先頭の登録者を追い越して繰り上げない
+	stored, _ := waitlistRepo.FindByID(behind.OrganizationID, behind.ID)
+	if stored.Status != domain.WaitlistStatusWaiting {
+		t.Errorf("Expected the entry behind the blocked one to keep waiting, got %s", stored.Status)
+	}
+}
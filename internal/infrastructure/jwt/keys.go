@@ -0,0 +1,218 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ErrNoSigningKey 署名に使う秘密鍵がない
+var ErrNoSigningKey = errors.New("no signing key configured")
+
+// minRSAKeyBits 受け付ける RSA 鍵の最小サイズ
+const minRSAKeyBits = 2048
+
+// Key kid で識別する署名鍵
+// 秘密鍵を持つ鍵は署名と検証に、公開鍵のみの鍵（ローテーションで退役させた鍵など）は検証にだけ使う
+type Key struct {
+	ID      string
+	method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// CanSign 秘密鍵を持ち、署名に使えるかチェック
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// Algorithm JWT の alg（RSA 鍵は RS256、Ed25519 鍵は EdDSA）
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// ParseKeyPEM PEM 形式の鍵を読み込む
+// 秘密鍵は PKCS#8（PRIVATE KEY）と PKCS#1（RSA PRIVATE KEY）、公開鍵は PKIX（PUBLIC KEY）に対応する
+func ParseKeyPEM(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %s: no PEM block found", kid)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %s: unsupported PEM block %q", kid, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("key %s: %w", kid, err)
+	}
+
+	key := &Key{ID: kid}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodRS256, k, &k.PublicKey
+	case *rsa.PublicKey:
+		key.method, key.public = jwt.SigningMethodRS256, k
+	case ed25519.PrivateKey:
+		key.method, key.private, key.public = jwt.SigningMethodEdDSA, k, k.Public()
+	case ed25519.PublicKey:
+		key.method, key.public = jwt.SigningMethodEdDSA, k
+	default:
+		return nil, fmt.Errorf("key %s: only RSA and Ed25519 keys are supported", kid)
+	}
+
+	if public, ok := key.public.(*rsa.PublicKey); ok && public.N.BitLen() < minRSAKeyBits {
+		return nil, fmt.Errorf("key %s: RSA keys must be at least %d bits", kid, minRSAKeyBits)
+	}
+	return key, nil
+}
+
+// KeySet トークンの署名・検証に使う鍵の集合
+// 署名には signing の鍵だけを使い、検証にはトークンの kid ヘッダーに一致するすべての鍵を使う
+type KeySet struct {
+	keys    map[string]*Key
+	signing *Key
+}
+
+// NewKeySet 鍵の集合を作成し、signingKID の鍵で署名する
+// signingKID が空の場合、秘密鍵を持つ鍵が1つだけならその鍵で署名する
+func NewKeySet(keys []*Key, signingKID string) (*KeySet, error) {
+	set := &KeySet{keys: make(map[string]*Key, len(keys))}
+	var signable []*Key
+	for _, key := range keys {
+		if _, exists := set.keys[key.ID]; exists {
+			return nil, fmt.Errorf("duplicate key id %q", key.ID)
+		}
+		set.keys[key.ID] = key
+		if key.CanSign() {
+			signable = append(signable, key)
+		}
+	}
+
+	switch {
+	case signingKID != "":
+		key, ok := set.keys[signingKID]
+		if !ok || !key.CanSign() {
+			return nil, fmt.Errorf("signing key %q not found or has no private key", signingKID)
+		}
+		set.signing = key
+	case len(signable) == 1:
+		set.signing = signable[0]
+	case len(signable) == 0:
+		return nil, ErrNoSigningKey
+	default:
+		return nil, errors.New("several private keys found; set JWT_SIGNING_KEY_ID to choose one")
+	}
+	return set, nil
+}
+
+// LoadKeySet ディレクトリ内の <kid>.pem ファイルから鍵の集合を読み込む
+func LoadKeySet(dir, signingKID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+
+	keys := make([]*Key, 0, len(paths))
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseKeyPEM(strings.TrimSuffix(filepath.Base(path), ".pem"), data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeySet(keys, signingKID)
+}
+
+// LoadKeySetFromEnv 環境変数 JWT_KEYS_DIR と JWT_SIGNING_KEY_ID から鍵の集合を読み込む
+// JWT_KEYS_DIR が未設定なら nil を返し、JWT_SECRET による HS256 の署名を使い続ける
+func LoadKeySetFromEnv() (*KeySet, error) {
+	dir := os.Getenv("JWT_KEYS_DIR")
+	if dir == "" {
+		return nil, nil
+	}
+	return LoadKeySet(dir, os.Getenv("JWT_SIGNING_KEY_ID"))
+}
+
+// SigningKeyID 署名に使う鍵の kid
+func (s *KeySet) SigningKeyID() string {
+	return s.signing.ID
+}
+
+// verificationKey トークンの kid と alg に一致する検証用の公開鍵
+func (s *KeySet) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.keys[kid]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", kid)
+	}
+	// 鍵と異なるアルゴリズムのトークンは受け付けない
+	if token.Method.Alg() != key.Algorithm() {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return key.public, nil
+}
+
+// JWK JSON Web Key（RFC 7517）の公開鍵
+type JWK struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	N         string `json:"n,omitempty"`
+	E         string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+// JWKSet JSON Web Key Set
+type JWKSet struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS 検証に使うすべての公開鍵（kid 順）
+func (s *KeySet) JWKS() *JWKSet {
+	set := &JWKSet{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		jwk := JWK{KeyID: key.ID, Use: "sig", Algorithm: key.Algorithm()}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyID < set.Keys[j].KeyID })
+	return set
+}
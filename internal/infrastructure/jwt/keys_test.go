@@ -0,0 +1,187 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func writeRSAKey(t *testing.T, dir, kid string, bits int) *rsa.PrivateKey {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	writePEM(t, dir, kid, "PRIVATE KEY", der)
+	return key
+}
+
+func writeEd25519Key(t *testing.T, dir, kid string) ed25519.PrivateKey {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+	der, _ := x509.MarshalPKCS8PrivateKey(key)
+	writePEM(t, dir, kid, "PRIVATE KEY", der)
+	return key
+}
+
+func writePEM(t *testing.T, dir, kid, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(filepath.Join(dir, kid+".pem"), data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+}
+
+func loadKeySet(t *testing.T, dir, signingKID string) *KeySet {
+	t.Helper()
+	set, err := LoadKeySet(dir, signingKID)
+	if err != nil {
+		t.Fatalf("LoadKeySet() error = %v", err)
+	}
+	UseKeySet(set)
+	t.Cleanup(func() { UseKeySet(nil) })
+	return set
+}
+
+func TestAsymmetricSigning(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		write func(t *testing.T, dir string)
+		alg   string
+	}{
+		{"RS256", func(t *testing.T, dir string) { writeRSAKey(t, dir, "rsa-1", 2048) }, "RS256"},
+		{"EdDSA", func(t *testing.T, dir string) { writeEd25519Key(t, dir, "ed-1") }, "EdDSA"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			tt.write(t, dir)
+			set := loadKeySet(t, dir, "")
+
+			token, _, err := GenerateToken(1, "test@example.com", "member", 1)
+			if err != nil {
+				t.Fatalf("GenerateToken() error = %v", err)
+			}
+
+			parsed, _, err := jwt.NewParser().ParseUnverified(token, &Claims{})
+			if err != nil {
+				t.Fatalf("ParseUnverified() error = %v", err)
+			}
+			if parsed.Method.Alg() != tt.alg || parsed.Header["kid"] != set.SigningKeyID() {
+				t.Errorf("Expected alg %s and kid %s, got %v", tt.alg, set.SigningKeyID(), parsed.Header)
+			}
+
+			claims, err := ValidateToken(token)
+			if err != nil {
+				t.Fatalf("ValidateToken() error = %v", err)
+			}
+			if claims.UserID != 1 {
+				t.Errorf("Expected UserID 1, got %v", claims.UserID)
+			}
+		})
+	}
+}
+
+func TestKeyRotationKeepsOldKeysForVerification(t *testing.T) {
+	dir := t.TempDir()
+	old := writeRSAKey(t, dir, "2026-01", 2048)
+	loadKeySet(t, dir, "")
+
+	oldToken, _, err := GenerateToken(1, "test@example.com", "member", 1)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	// 新しい鍵を追加して署名鍵を切り替え、古い鍵は公開鍵だけを残す
+	writeEd25519Key(t, dir, "2026-02")
+	der, _ := x509.MarshalPKIXPublicKey(&old.PublicKey)
+	writePEM(t, dir, "2026-01", "PUBLIC KEY", der)
+	set := loadKeySet(t, dir, "2026-02")
+
+	if _, err := ValidateToken(oldToken); err != nil {
+		t.Errorf("Expected tokens signed with the retired key to stay valid, got %v", err)
+	}
+
+	newToken, _, err := GenerateToken(1, "test@example.com", "member", 1)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+	if _, err := ValidateToken(newToken); err != nil {
+		t.Errorf("ValidateToken() error = %v", err)
+	}
+
+	jwks := set.JWKS()
+	if len(jwks.Keys) != 2 || jwks.Keys[0].KeyID != "2026-01" || jwks.Keys[1].KeyID != "2026-02" {
+		t.Fatalf("Expected both keys in the JWKS, got %+v", jwks.Keys)
+	}
+	if jwks.Keys[0].KeyType != "RSA" || jwks.Keys[0].N == "" || jwks.Keys[0].E != "AQAB" {
+		t.Errorf("Unexpected RSA JWK %+v", jwks.Keys[0])
+	}
+	if jwks.Keys[1].KeyType != "OKP" || jwks.Keys[1].Curve != "Ed25519" || jwks.Keys[1].X == "" {
+		t.Errorf("Unexpected Ed25519 JWK %+v", jwks.Keys[1])
+	}
+
+	// 公開鍵しかない鍵では署名できない
+	if _, err := LoadKeySet(dir, "2026-01"); err == nil {
+		t.Error("Expected a public-only key to be rejected as the signing key")
+	}
+
+	// 鍵を削除すると、その鍵で署名したトークンは受け付けない
+	if err := os.Remove(filepath.Join(dir, "2026-01.pem")); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	loadKeySet(t, dir, "")
+	if _, err := ValidateToken(oldToken); err == nil {
+		t.Error("Expected tokens signed with a removed key to be rejected")
+	}
+}
+
+func TestValidateTokenRejectsHS256WithKeySet(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	hsToken, _, err := GenerateToken(1, "test@example.com", "member", 1)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	dir := t.TempDir()
+	writeRSAKey(t, dir, "rsa-1", 2048)
+	loadKeySet(t, dir, "")
+
+	if _, err := ValidateToken(hsToken); err == nil {
+		t.Error("Expected HS256 tokens to be rejected once asymmetric keys are configured")
+	}
+}
+
+func TestLoadKeySetErrors(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := LoadKeySet(dir, ""); err != ErrNoSigningKey {
+		t.Errorf("Expected ErrNoSigningKey for an empty directory, got %v", err)
+	}
+
+	writeRSAKey(t, dir, "small", 1024)
+	if _, err := LoadKeySet(dir, ""); err == nil {
+		t.Error("Expected RSA keys under 2048 bits to be rejected")
+	}
+	os.Remove(filepath.Join(dir, "small.pem"))
+
+	writeEd25519Key(t, dir, "a")
+	writeEd25519Key(t, dir, "b")
+	if _, err := LoadKeySet(dir, ""); err == nil {
+		t.Error("Expected several private keys without a signing key ID to be rejected")
+	}
+	if _, err := LoadKeySet(dir, "missing"); err == nil {
+		t.Error("Expected an unknown signing key ID to be rejected")
+	}
+}
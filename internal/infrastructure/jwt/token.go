@@ -1,54 +1,170 @@
 package jwt
 
 import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"log"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 )
 
+// ErrTokenRevoked ログアウトなどで失効させたトークン
+var ErrTokenRevoked = errors.New("token has been revoked")
+
+// DefaultAccessTokenTTL アクセストークンの有効期限の既定値
+const DefaultAccessTokenTTL = 15 * time.Minute
+
 // Claims JWTクレーム
+// Role は発行時点のユーザー権限（権限の変更は次回のトークン発行から反映される）
+// OrganizationID はログイン時に選択した組織（テナント）
+// RegisteredClaims.ID（jti）は失効リストでトークンを特定するために使う
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
+	UserID         uint   `json:"user_id"`
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+	OrganizationID uint   `json:"org_id"`
 	jwt.RegisteredClaims
 }
 
-// GenerateToken JWTトークンを生成
-func GenerateToken(userID uint, email string) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", jwt.ErrSignatureInvalid
+// RevocationList 有効期限前に失効させたトークンの一覧
+type RevocationList interface {
+	IsRevoked(tokenID string) (bool, error)
+}
+
+var revocationList RevocationList
+
+// UseRevocationList ValidateToken が参照する失効リストを設定
+func UseRevocationList(list RevocationList) {
+	revocationList = list
+}
+
+var keySet *KeySet
+
+// UseKeySet GenerateToken と ValidateToken が使う鍵の集合を設定
+// 設定しない場合は環境変数 JWT_SECRET による HS256 で署名・検証する
+func UseKeySet(set *KeySet) {
+	keySet = set
+}
+
+// PublicKeys 他のサービスがトークンを検証するための公開鍵（HS256 の場合は空）
+func PublicKeys() *JWKSet {
+	if keySet == nil {
+		return &JWKSet{Keys: []JWK{}}
+	}
+	return keySet.JWKS()
+}
+
+// AccessTokenTTL 環境変数 ACCESS_TOKEN_TTL_MINUTES からアクセストークンの有効期限を取得
+func AccessTokenTTL() time.Duration {
+	value := os.Getenv("ACCESS_TOKEN_TTL_MINUTES")
+	if value == "" {
+		return DefaultAccessTokenTTL
 	}
 
-	expirationTime := time.Now().Add(24 * time.Hour)
+	minutes, err := strconv.Atoi(value)
+	if err != nil || minutes <= 0 {
+		log.Printf("invalid ACCESS_TOKEN_TTL_MINUTES=%q, using default %s", value, DefaultAccessTokenTTL)
+		return DefaultAccessTokenTTL
+	}
+	return time.Duration(minutes) * time.Minute
+}
+
+// GenerateToken 有効期限の短いアクセストークン（JWT）を生成し、署名したクレームとともに返す
+// 鍵の集合を設定している場合は署名鍵の kid をヘッダーに付けて RS256 または EdDSA で署名する
+func GenerateToken(userID uint, email, role string, organizationID uint) (string, *Claims, error) {
+	tokenID, err := newTokenID()
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := time.Now()
 	claims := &Claims{
-		UserID: userID,
-		Email:  email,
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		OrganizationID: organizationID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(expirationTime),
+			ID:        tokenID,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(AccessTokenTTL())),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(secret))
+	token, err := sign(claims)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
 }
 
-// ValidateToken JWTトークンを検証
-func ValidateToken(tokenString string) (*Claims, error) {
+func sign(claims *Claims) (string, error) {
+	if keySet != nil {
+		token := jwt.NewWithClaims(keySet.signing.method, claims)
+		token.Header["kid"] = keySet.signing.ID
+		return token.SignedString(keySet.signing.private)
+	}
+
 	secret := os.Getenv("JWT_SECRET")
 	if secret == "" {
+		return "", jwt.ErrSignatureInvalid
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+}
+
+// ValidateToken JWTトークンを検証
+// 失効リストが設定されている場合、失効させたトークンは ErrTokenRevoked になる
+// jti のない古いトークンは失効させられないため受け付けない
+// 鍵の集合を設定している場合は kid に一致する公開鍵で検証し、HS256 のトークンは受け付けない
+func ValidateToken(tokenString string) (*Claims, error) {
+	keyFunc, methods := verification()
+	if keyFunc == nil {
 		return nil, jwt.ErrSignatureInvalid
 	}
 
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		return []byte(secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, keyFunc, jwt.WithValidMethods(methods))
 
-	if err != nil || !token.Valid {
+	if err != nil || !token.Valid || claims.ID == "" || claims.ExpiresAt == nil {
 		return nil, jwt.ErrSignatureInvalid
 	}
 
+	if revocationList != nil {
+		revoked, err := revocationList.IsRevoked(claims.ID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	return claims, nil
 }
+
+// verification 検証に使う鍵の取得方法と受け付けるアルゴリズム（鍵がなければ nil）
+func verification() (jwt.Keyfunc, []string) {
+	if keySet != nil {
+		return keySet.verificationKey, []string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}
+	}
+
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return nil, nil
+	}
+	return func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	}, []string{jwt.SigningMethodHS256.Alg()}
+}
+
+// newTokenID トークンを一意に識別する jti を生成
+func newTokenID() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
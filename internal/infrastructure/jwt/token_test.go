@@ -3,6 +3,7 @@ package jwt
 import (
 	"os"
 	"testing"
+	"time"
 )
 
 func TestJWTTokenGeneration(t *testing.T) {
@@ -10,7 +11,7 @@ func TestJWTTokenGeneration(t *testing.T) {
 	os.Unsetenv("JWT_SECRET")
 
 	// Test token generation without JWT_SECRET should fail
-	_, err := GenerateToken(1, "test@example.com")
+	_, _, err := GenerateToken(1, "test@example.com", "member", 1)
 	if err == nil {
 		t.Error("GenerateToken() should fail without JWT_SECRET environment variable")
 	}
@@ -25,7 +26,7 @@ func TestJWTTokenGeneration(t *testing.T) {
 	os.Setenv("JWT_SECRET", "test-secret-key")
 	defer os.Unsetenv("JWT_SECRET")
 
-	token, err := GenerateToken(1, "test@example.com")
+	token, _, err := GenerateToken(1, "test@example.com", "staff", 2)
 	if err != nil {
 		t.Errorf("GenerateToken() error = %v", err)
 	}
@@ -47,4 +48,59 @@ func TestJWTTokenGeneration(t *testing.T) {
 	if claims.Email != "test@example.com" {
 		t.Errorf("Expected email test@example.com, got %v", claims.Email)
 	}
+	if claims.Role != "staff" {
+		t.Errorf("Expected role staff, got %v", claims.Role)
+	}
+	if claims.OrganizationID != 2 {
+		t.Errorf("Expected OrganizationID 2, got %v", claims.OrganizationID)
+	}
+}
+
+type fakeRevocationList map[string]bool
+
+func (l fakeRevocationList) IsRevoked(tokenID string) (bool, error) {
+	return l[tokenID], nil
+}
+
+func TestValidateTokenChecksRevocationList(t *testing.T) {
+	os.Setenv("JWT_SECRET", "test-secret-key")
+	defer os.Unsetenv("JWT_SECRET")
+
+	revoked := fakeRevocationList{}
+	UseRevocationList(revoked)
+	defer UseRevocationList(nil)
+
+	token, _, err := GenerateToken(1, "test@example.com", "member", 1)
+	if err != nil {
+		t.Fatalf("GenerateToken() error = %v", err)
+	}
+
+	claims, err := ValidateToken(token)
+	if err != nil {
+		t.Fatalf("ValidateToken() error = %v", err)
+	}
+	if claims.ID == "" {
+		t.Fatal("Expected the token to have an ID")
+	}
+	if ttl := claims.ExpiresAt.Sub(claims.IssuedAt.Time); ttl != DefaultAccessTokenTTL {
+		t.Errorf("Expected a %s access token, got %s", DefaultAccessTokenTTL, ttl)
+	}
+
+	revoked[claims.ID] = true
+	if _, err := ValidateToken(token); err != ErrTokenRevoked {
+		t.Errorf("Expected ErrTokenRevoked, got %v", err)
+	}
+}
+
+func TestAccessTokenTTL(t *testing.T) {
+	os.Setenv("ACCESS_TOKEN_TTL_MINUTES", "5")
+	defer os.Unsetenv("ACCESS_TOKEN_TTL_MINUTES")
+	if got := AccessTokenTTL(); got != 5*time.Minute {
+		t.Errorf("AccessTokenTTL() = %s, want 5m", got)
+	}
+
+	os.Setenv("ACCESS_TOKEN_TTL_MINUTES", "0")
+	if got := AccessTokenTTL(); got != DefaultAccessTokenTTL {
+		t.Errorf("AccessTokenTTL() = %s, want default %s", got, DefaultAccessTokenTTL)
+	}
 }
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrInvalidHeader 宛先や件名に改行を含むメール（ヘッダーインジェクション対策）
+var ErrInvalidHeader = errors.New("mail header must not contain line breaks")
+
+// Message 送信するメール（本文はプレーンテキスト）
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Sender メール送信のインターフェース
+// SMTP や外部サービスを使う場合はこのインターフェースを実装して差し替える
+type Sender interface {
+	Send(message *Message) error
+}
+
+// NewSender 環境変数 MAIL_OUTBOX_DIR が設定されていればそのディレクトリに保存し、なければログに出力する送信者を作成
+// どちらもローカル開発用で、実際にはメールを送信しない
+func NewSender() Sender {
+	if dir := os.Getenv("MAIL_OUTBOX_DIR"); dir != "" {
+		return &FileSender{Dir: dir}
+	}
+	return &LogSender{}
+}
+
+// LogSender メールをログに出力する
+type LogSender struct{}
+
+func (s *LogSender) Send(message *Message) error {
+	if err := validate(message); err != nil {
+		return err
+	}
+	log.Printf("mail to=%s subject=%q\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
+
+// FileSender メールを1通ずつ .eml ファイルとして Dir に保存する
+type FileSender struct {
+	Dir string
+}
+
+func (s *FileSender) Send(message *Message) error {
+	if err := validate(message); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(s.Dir, time.Now().UTC().Format("20060102T150405")+"-*.eml")
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fmt.Fprintf(f, "To: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s",
+		message.To, message.Subject, message.Body)
+	return err
+}
+
+func validate(message *Message) error {
+	if strings.ContainsAny(message.To+message.Subject, "\r\n") {
+		return ErrInvalidHeader
+	}
+	return nil
+}
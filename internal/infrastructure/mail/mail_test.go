@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFileSender(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "outbox")
+	sender := &FileSender{Dir: dir}
+
+	err := sender.Send(&Message{To: "user@example.com", Subject: "Hello", Body: "Body text"})
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if len(files) != 1 {
+		t.Fatalf("Expected 1 mail file, got %d", len(files))
+	}
+	content, _ := os.ReadFile(files[0])
+	for _, want := range []string{"To: user@example.com\r\n", "Subject: Hello\r\n", "\r\n\r\nBody text"} {
+		if !strings.Contains(string(content), want) {
+			t.Errorf("Mail file does not contain %q:\n%s", want, content)
+		}
+	}
+}
+
+func TestSenderRejectsHeaderInjection(t *testing.T) {
+	senders := []Sender{&LogSender{}, &FileSender{Dir: t.TempDir()}}
+	for _, sender := range senders {
+		err := sender.Send(&Message{To: "user@example.com\r\nBcc: other@example.com", Subject: "Hello"})
+		if err != ErrInvalidHeader {
+			t.Errorf("%T: expected ErrInvalidHeader, got %v", sender, err)
+		}
+	}
+}
@@ -0,0 +1,27 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// Run ctx がキャンセルされるまで interval ごとに task を実行
+// task のエラーはログに出力して次回の実行を続ける
+func Run(ctx context.Context, name string, interval time.Duration, task func(now time.Time) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	log.Printf("Job %s started (interval %s)", name, interval)
+	for {
+		select {
+		case <-ctx.Done():
+			log.Printf("Job %s stopped", name)
+			return
+		case now := <-ticker.C:
+			if err := task(now); err != nil {
+				log.Printf("Job %s failed: %v", name, err)
+			}
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// BlackoutRepository 休業日リポジトリインターフェース
+// 共通の休業日（resource_id なし）も組織ごとに分離される
+type BlackoutRepository interface {
+	Create(blackout *domain.BlackoutDate) error
+	CreateAll(blackouts []*domain.BlackoutDate) error
+	FindByID(organizationID, id uint) (*domain.BlackoutDate, error)
+	FindAll(organizationID uint, resourceID *uint) ([]*domain.BlackoutDate, error)
+	FindForResource(organizationID, resourceID uint, from, to time.Time) (domain.Blackouts, error)
+	Update(blackout *domain.BlackoutDate) error
+	Delete(organizationID, id uint) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// EmailVerificationRepository メールアドレス確認トークンリポジトリインターフェース
+type EmailVerificationRepository interface {
+	Create(token *domain.EmailVerificationToken) error
+	FindByHash(tokenHash string) (*domain.EmailVerificationToken, error)
+	// FindCreatedSince ユーザーに since 以降に発行したトークンを取得（再送制限に使う）
+	FindCreatedSince(userID uint, since time.Time) ([]*domain.EmailVerificationToken, error)
+	// MarkUsed 未使用の場合のみ used_at を保存する（同じトークンで同時に確認しても成功するのは1回のみ）
+	MarkUsed(token *domain.EmailVerificationToken) (bool, error)
+	// InvalidateByUserID ユーザーの未使用のトークンをすべて使用済みにする
+	InvalidateByUserID(userID uint, now time.Time) error
+	DeleteExpiredBefore(cutoff time.Time) error
+}
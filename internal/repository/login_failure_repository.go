@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// LoginFailureRepository IP アドレスごとのログイン失敗リポジトリインターフェース
+type LoginFailureRepository interface {
+	Create(failure *domain.LoginFailure) error
+	// CountSince IP アドレスの since 以降の失敗回数と最後の失敗日時を取得（失敗がなければ 0 とゼロ値）
+	CountSince(ipAddress string, since time.Time) (int, time.Time, error)
+	DeleteBefore(cutoff time.Time) error
+}
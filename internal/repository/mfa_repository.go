@@ -0,0 +1,29 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// MFAChallengeRepository MFA チャレンジリポジトリインターフェース
+type MFAChallengeRepository interface {
+	Create(challenge *domain.MFAChallenge) error
+	FindByHash(tokenHash string) (*domain.MFAChallenge, error)
+	// MarkUsed 未使用の場合のみ used_at を保存する（同じチャレンジで同時に認証しても成功するのは1回のみ）
+	MarkUsed(challenge *domain.MFAChallenge) (bool, error)
+	// ReserveAttempt 未使用で確認回数が MaxMFAAttempts 未満の場合のみ回数を1つ増やす（予約できなければ false）
+	ReserveAttempt(challenge *domain.MFAChallenge) (bool, error)
+	DeleteExpiredBefore(cutoff time.Time) error
+}
+
+// RecoveryCodeRepository リカバリーコードリポジトリインターフェース
+type RecoveryCodeRepository interface {
+	// ReplaceForUser ユーザーのリカバリーコードを全件入れ替え
+	ReplaceForUser(userID uint, codes []*domain.RecoveryCode) error
+	// FindUnused ユーザーの未使用のリカバリーコードをハッシュで取得（見つからない場合は ErrInvalidMFACode）
+	FindUnused(userID uint, codeHash string) (*domain.RecoveryCode, error)
+	// MarkUsed 未使用の場合のみ used_at を保存する
+	MarkUsed(code *domain.RecoveryCode, now time.Time) (bool, error)
+	DeleteByUserID(userID uint) error
+}
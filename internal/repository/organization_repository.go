@@ -0,0 +1,16 @@
+package repository
+
+import "reservation-system/internal/domain"
+
+// OrganizationRepository 組織リポジトリインターフェース
+type OrganizationRepository interface {
+	Create(organization *domain.Organization) error
+	FindByID(id uint) (*domain.Organization, error)
+	FindBySlug(slug string) (*domain.Organization, error)
+	FindByUserID(userID uint) ([]*domain.Organization, error)
+	AddMember(membership *domain.Membership) error
+	RemoveMember(organizationID, userID uint) error
+	IsMember(organizationID, userID uint) (bool, error)
+	FindMembership(organizationID, userID uint) (*domain.Membership, error)
+	UpdateMemberRole(membership *domain.Membership) error
+}
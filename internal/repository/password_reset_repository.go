@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// PasswordResetRepository パスワード再設定トークンリポジトリインターフェース
+type PasswordResetRepository interface {
+	Create(token *domain.PasswordResetToken) error
+	FindByHash(tokenHash string) (*domain.PasswordResetToken, error)
+	// MarkUsed 未使用の場合のみ used_at を保存する（同じトークンで同時に再設定しても成功するのは1回のみ）
+	MarkUsed(token *domain.PasswordResetToken) (bool, error)
+	// InvalidateByUserID ユーザーの未使用のトークンをすべて使用済みにする
+	InvalidateByUserID(userID uint, now time.Time) error
+	DeleteExpiredBefore(cutoff time.Time) error
+}
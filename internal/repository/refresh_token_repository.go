@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// RefreshTokenRepository リフレッシュトークンリポジトリインターフェース
+type RefreshTokenRepository interface {
+	Create(token *domain.RefreshToken) error
+	FindByHash(tokenHash string) (*domain.RefreshToken, error)
+	// MarkUsed 未使用かつ失効していない場合のみ used_at を保存する
+	// 同じトークンで同時にリフレッシュされた場合、true を返すのは1リクエストのみ
+	MarkUsed(token *domain.RefreshToken) (bool, error)
+	RevokeFamily(familyID string, now time.Time) error
+	// FindWithLiveAccessToken 同時に発行したアクセストークンが now の時点で有効期限内のものを取得
+	FindWithLiveAccessToken(userID uint, now time.Time) ([]*domain.RefreshToken, error)
+	RevokeByUserID(userID uint, now time.Time) error
+	DeleteExpiredBefore(cutoff time.Time) error
+}
@@ -1,13 +1,28 @@
 package repository
 
-import "reservation-system/internal/domain"
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
 
 // ReservationRepository 予約リポジトリインターフェース
+// 参照系のメソッドは organizationID の組織の予約だけを対象にする
+// FindPendingCreatedBefore と FindConfirmedStartedBefore はバックグラウンドジョブ用で、全組織の予約を返す
 type ReservationRepository interface {
 	Create(reservation *domain.Reservation) error
-	FindByID(id uint) (*domain.Reservation, error)
-	FindByUserID(userID uint) ([]*domain.Reservation, error)
+	FindByID(organizationID, id uint) (*domain.Reservation, error)
+	FindByUserID(organizationID, userID uint) ([]*domain.Reservation, error)
+	FindByResourceID(organizationID, resourceID uint) ([]*domain.Reservation, error)
+	FindBySeriesID(organizationID, seriesID uint) ([]*domain.Reservation, error)
 	Update(reservation *domain.Reservation) error
-	Delete(id uint) error
-	CountByDateAndTime(date string, startTime, endTime string) (int, error)
+	UpdateAllIfStatus(reservations []*domain.Reservation, expected []domain.ReservationStatus) ([]*domain.Reservation, error)
+	UpdateIfStatus(reservation *domain.Reservation, expected domain.ReservationStatus) (bool, error)
+	FindPendingCreatedBefore(cutoff time.Time) ([]*domain.Reservation, error)
+	FindConfirmedStartedBefore(cutoff time.Time) ([]*domain.Reservation, error)
+	FindAwaitingApproval(organizationID, approverID uint) ([]*domain.Reservation, error)
+	Delete(organizationID, id uint) error
+	CreateIfAvailable(reservation *domain.Reservation) error
+	UpdateIfAvailable(reservation *domain.Reservation) error
+	FindActiveByResourceAndPeriod(organizationID, resourceID uint, startAt, endAt time.Time) ([]*domain.Reservation, error)
 }
@@ -0,0 +1,9 @@
+package repository
+
+import "reservation-system/internal/domain"
+
+// ReservationSeriesRepository 繰り返し予約リポジトリインターフェース
+type ReservationSeriesRepository interface {
+	Create(series *domain.ReservationSeries) error
+	FindByID(organizationID, id uint) (*domain.ReservationSeries, error)
+}
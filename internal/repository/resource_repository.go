@@ -0,0 +1,18 @@
+package repository
+
+import "reservation-system/internal/domain"
+
+// ResourceRepository リソースリポジトリインターフェース
+// 参照系のメソッドは organizationID の組織のリソースだけを対象にする
+type ResourceRepository interface {
+	Create(resource *domain.Resource) error
+	FindByID(organizationID, id uint) (*domain.Resource, error)
+	FindAll(organizationID uint) ([]*domain.Resource, error)
+	Update(resource *domain.Resource) error
+	ReplaceSchedule(resource *domain.Resource) error
+	ReplaceCancellationPolicy(resource *domain.Resource) error
+	ReplaceApprovers(resource *domain.Resource) error
+	ReplaceStaff(resource *domain.Resource) error
+	FindIDsByStaff(organizationID, userID uint) ([]uint, error)
+	Delete(organizationID, id uint) error
+}
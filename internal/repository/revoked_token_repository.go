@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// RevokedTokenRepository アクセストークンの失効リストのリポジトリインターフェース
+type RevokedTokenRepository interface {
+	Revoke(token *domain.RevokedToken) error
+	IsRevoked(tokenID string) (bool, error)
+	DeleteExpiredBefore(cutoff time.Time) error
+}
@@ -10,4 +10,18 @@ type UserRepository interface {
 	Update(user *domain.User) error
 	Delete(id uint) error
 	Exists(email string) (bool, error)
+	// IncrementFailedAttempts 失敗回数をデータベース上で1つ増やし、増やした後の回数を返す（同時に失敗しても取りこぼさない）
+	IncrementFailedAttempts(id uint) (int, error)
+	// MarkEmailVerified 未確認の場合のみ email_verified_at を保存する（同時に確認しても成功するのは1回のみ）
+	MarkEmailVerified(user *domain.User) (bool, error)
+	// UpdatePassword password のみを保存する
+	UpdatePassword(user *domain.User) error
+	// UpdateLockout failed_attempts と locked_until のみを保存する
+	UpdateLockout(user *domain.User) error
+	// UpdateTOTP totp_secret・totp_enabled_at・totp_last_used_step のみを保存する
+	UpdateTOTP(user *domain.User) error
+	// EnableTOTP 登録中の秘密鍵が変わっておらず、コードの時間ステップが未使用の場合のみ totp_enabled_at と totp_last_used_step を保存する
+	EnableTOTP(user *domain.User) (bool, error)
+	// UseTOTPStep 保存済みの時間ステップより新しい場合のみ totp_last_used_step を保存する（同じコードで同時に認証しても成功するのは1回のみ）
+	UseTOTPStep(user *domain.User) (bool, error)
 }
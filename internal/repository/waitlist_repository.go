@@ -0,0 +1,13 @@
+package repository
+
+import "reservation-system/internal/domain"
+
+// WaitlistRepository キャンセル待ちリポジトリインターフェース
+type WaitlistRepository interface {
+	Create(entry *domain.WaitlistEntry) error
+	FindByID(organizationID, id uint) (*domain.WaitlistEntry, error)
+	// UpdateIfWaiting DB 上でまだ待っている場合のみステータスを保存する（繰り上げと同時に取り下げても、どちらか一方だけが成功する）
+	UpdateIfWaiting(entry *domain.WaitlistEntry) (bool, error)
+	Position(entry *domain.WaitlistEntry) (int, error)
+	PromoteWaiting(organizationID, resourceID uint, timeSlot *domain.TimeSlot) ([]*domain.WaitlistEntry, error)
+}
@@ -1,19 +1,38 @@
 package usecase
 
 import (
+	"time"
+
 	"reservation-system/internal/domain"
 	"reservation-system/internal/infrastructure/db"
 	"reservation-system/internal/infrastructure/jwt"
+	"reservation-system/internal/infrastructure/mail"
 	"reservation-system/internal/repository"
 )
 
 type AuthUseCase struct {
-	userRepo repository.UserRepository
+	userRepo              repository.UserRepository
+	organizationRepo      repository.OrganizationRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	revokedTokenRepo      repository.RevokedTokenRepository
+	passwordResetRepo     repository.PasswordResetRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mfaChallengeRepo      repository.MFAChallengeRepository
+	mailSender            mail.Sender
+	loginGuard            *loginGuard
 }
 
 func NewAuthUseCase() *AuthUseCase {
 	return &AuthUseCase{
-		userRepo: db.NewUserRepository(),
+		userRepo:              db.NewUserRepository(),
+		organizationRepo:      db.NewOrganizationRepository(),
+		refreshTokenRepo:      db.NewRefreshTokenRepository(),
+		revokedTokenRepo:      db.NewRevokedTokenRepository(),
+		passwordResetRepo:     db.NewPasswordResetRepository(),
+		emailVerificationRepo: db.NewEmailVerificationRepository(),
+		mfaChallengeRepo:      db.NewMFAChallengeRepository(),
+		mailSender:            mail.NewSender(),
+		loginGuard:            newLoginGuard(),
 	}
 }
 
@@ -24,10 +43,14 @@ type RegisterRequest struct {
 }
 
 type RegisterResponse struct {
-	Token string       `json:"token"`
-	User  *domain.User `json:"user"`
+	Token        string               `json:"token"`
+	RefreshToken string               `json:"refresh_token"`
+	User         *MemberResponse      `json:"user"`
+	Organization *domain.Organization `json:"organization"`
 }
 
+// Register ユーザーを登録し、確認メールを送る
+// メールアドレスを確認するまでログインはできるが予約は作成できない
 func (uc *AuthUseCase) Register(req *RegisterRequest) (*RegisterResponse, error) {
 	exists, err := uc.userRepo.Exists(req.Email)
 	if err != nil {
@@ -47,60 +70,270 @@ func (uc *AuthUseCase) Register(req *RegisterRequest) (*RegisterResponse, error)
 		return nil, err
 	}
 
-	token, err := jwt.GenerateToken(user.ID, user.Email)
+	organization, membership, err := joinDefaultOrganization(uc.organizationRepo, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sendVerification(uc.emailVerificationRepo, uc.mailSender, user, time.Now(), EmailVerificationTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	token, refreshToken, err := issueTokens(uc.refreshTokenRepo, user, membership, "")
 	if err != nil {
 		return nil, err
 	}
 
 	return &RegisterResponse{
-		Token: token,
-		User: &domain.User{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		Token:        token,
+		RefreshToken: refreshToken,
+		Organization: organization,
+		User:         newMemberResponse(user, membership),
 	}, nil
 }
 
+// AuthRequest ログインリクエスト
+// OrganizationID を省略すると最初に所属した組織にログインする
+// IPAddress はハンドラーが設定する接続元で、IP アドレスごとの失敗回数の記録に使う
 type AuthRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	OrganizationID uint   `json:"organization_id"`
+	IPAddress      string `json:"-"`
 }
 
+// AuthResponse ログインレスポンス
+// 2段階認証を有効にしたユーザーにはトークンの代わりに MFARequired と MFAToken を返し、
+// /api/auth/mfa/verify で MFAToken と認証コードを引き換えにトークンを発行する
 type AuthResponse struct {
-	Token string       `json:"token"`
-	User  *domain.User `json:"user"`
+	Token        string               `json:"token,omitempty"`
+	RefreshToken string               `json:"refresh_token,omitempty"`
+	User         *MemberResponse      `json:"user,omitempty"`
+	Organization *domain.Organization `json:"organization,omitempty"`
+	MFARequired  bool                 `json:"mfa_required"`
+	MFAToken     string               `json:"mfa_token,omitempty"`
 }
 
+// Authenticate パスワードを確認してトークンを発行（2段階認証が有効なユーザーには MFA チャレンジを返す）
 func (uc *AuthUseCase) Authenticate(req *AuthRequest) (*AuthResponse, error) {
-	user, err := uc.userRepo.FindByEmail(req.Email)
+	user, err := uc.loginGuard.checkCredentials(req.Email, req.Password, req.IPAddress)
 	if err != nil {
-		return nil, domain.ErrInvalidCredentials
+		return nil, err
 	}
 
-	if err := user.CheckPassword(req.Password); err != nil {
-		return nil, domain.ErrInvalidCredentials
+	organization, membership, err := selectOrganization(uc.organizationRepo, user.ID, req.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsTOTPEnabled() {
+		mfaToken, err := startMFAChallenge(uc.mfaChallengeRepo, user.ID, organization.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &AuthResponse{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	err = uc.loginGuard.succeed(user)
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := jwt.GenerateToken(user.ID, user.Email)
+	token, refreshToken, err := issueTokens(uc.refreshTokenRepo, user, membership, "")
 	if err != nil {
 		return nil, err
 	}
 
 	return &AuthResponse{
-		Token: token,
-		User: &domain.User{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		Token:        token,
+		RefreshToken: refreshToken,
+		Organization: organization,
+		User:         newMemberResponse(user, membership),
 	}, nil
 }
 
 func (uc *AuthUseCase) ValidateToken(tokenString string) (*jwt.Claims, error) {
 	return jwt.ValidateToken(tokenString)
 }
+
+// PublicKeys アクセストークンを検証するための公開鍵（JWKS）
+func (uc *AuthUseCase) PublicKeys() *jwt.JWKSet {
+	return jwt.PublicKeys()
+}
+
+// RefreshRequest トークン再発行リクエスト
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RefreshResponse 再発行したアクセストークンと入れ替え後のリフレッシュトークン
+type RefreshResponse struct {
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Refresh リフレッシュトークンを使用済みにし、アクセストークンと新しいリフレッシュトークンを発行
+// 権限は再発行の時点の、トークンの組織での権限を反映する
+// 使用済みのトークンが再び使われた場合は盗用とみなし、同じファミリーのトークンをすべて失効させる
+// アカウントがロックされている場合や組織から外された場合も、同じファミリーを失効させて拒否する
+func (uc *AuthUseCase) Refresh(req *RefreshRequest) (*RefreshResponse, error) {
+	stored, err := uc.refreshTokenRepo.FindByHash(domain.HashToken(req.RefreshToken))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = stored.Use(now)
+	if err == domain.ErrRefreshTokenReused {
+		return nil, uc.revokeFamily(stored, now, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じトークンで同時にリフレッシュされた場合、後から来たリクエストは再利用として扱う
+	used, err := uc.refreshTokenRepo.MarkUsed(stored)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, uc.revokeFamily(stored, now, domain.ErrRefreshTokenReused)
+	}
+
+	user, err := uc.userRepo.FindByID(stored.UserID)
+	if err != nil {
+		return nil, uc.revokeFamily(stored, now, domain.ErrInvalidRefreshToken)
+	}
+
+	// ロックされたアカウントには再発行せず、ログインし直すまでセッションを使えなくする
+	if user.IsLocked(now) {
+		return nil, uc.revokeFamily(stored, now, domain.ErrAccountLocked)
+	}
+
+	// 組織から外されたユーザーには再発行しない
+	membership, err := uc.organizationRepo.FindMembership(stored.OrganizationID, user.ID)
+	if err == domain.ErrNotOrganizationMember {
+		return nil, uc.revokeFamily(stored, now, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token, refreshToken, err := issueTokens(uc.refreshTokenRepo, user, membership, stored.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// revokeFamily リフレッシュトークンのファミリーを失効させて reason を返す
+func (uc *AuthUseCase) revokeFamily(token *domain.RefreshToken, now time.Time, reason error) error {
+	if err := uc.refreshTokenRepo.RevokeFamily(token.FamilyID, now); err != nil {
+		return err
+	}
+	return reason
+}
+
+// LogoutRequest ログアウトリクエスト
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Logout 認証に使ったアクセストークンを失効リストに登録し、リフレッシュトークンのファミリーを失効させる
+// 他のユーザーのリフレッシュトークンや無効なリフレッシュトークンは無視する
+func (uc *AuthUseCase) Logout(req *LogoutRequest, principal *domain.Principal) error {
+	err := uc.revokedTokenRepo.Revoke(&domain.RevokedToken{
+		TokenID:   principal.TokenID,
+		ExpiresAt: principal.TokenExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+
+	if req.RefreshToken == "" {
+		return nil
+	}
+
+	stored, err := uc.refreshTokenRepo.FindByHash(domain.HashToken(req.RefreshToken))
+	if err == domain.ErrInvalidRefreshToken {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if stored.UserID != principal.UserID {
+		return nil
+	}
+
+	return uc.refreshTokenRepo.RevokeFamily(stored.FamilyID, time.Now())
+}
+
+// PurgeLoginFailures 古いログイン失敗の記録を削除
+func (uc *AuthUseCase) PurgeLoginFailures(now time.Time) error {
+	return uc.loginGuard.purgeLoginFailures(now)
+}
+
+// PurgeExpiredTokens 有効期限を過ぎたリフレッシュトークン・パスワード再設定トークン・メールアドレス確認トークン・MFA チャレンジと失効リストの登録を削除
+func (uc *AuthUseCase) PurgeExpiredTokens(now time.Time) error {
+	if err := uc.refreshTokenRepo.DeleteExpiredBefore(now); err != nil {
+		return err
+	}
+	if err := uc.passwordResetRepo.DeleteExpiredBefore(now); err != nil {
+		return err
+	}
+	if err := uc.emailVerificationRepo.DeleteExpiredBefore(now); err != nil {
+		return err
+	}
+	if err := uc.mfaChallengeRepo.DeleteExpiredBefore(now); err != nil {
+		return err
+	}
+	return uc.revokedTokenRepo.DeleteExpiredBefore(now)
+}
+
+// endSessions ユーザーのリフレッシュトークンをすべて失効させ、同時に発行したアクセストークンを失効リストに登録
+func endSessions(refreshTokenRepo repository.RefreshTokenRepository, revokedTokenRepo repository.RevokedTokenRepository, userID uint, now time.Time) error {
+	tokens, err := refreshTokenRepo.FindWithLiveAccessToken(userID, now)
+	if err != nil {
+		return err
+	}
+
+	for _, token := range tokens {
+		err := revokedTokenRepo.Revoke(&domain.RevokedToken{
+			TokenID:   token.AccessTokenID,
+			ExpiresAt: token.AccessTokenExpiresAt,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return refreshTokenRepo.RevokeByUserID(userID, now)
+}
+
+// issueTokens アクセストークンとリフレッシュトークンを発行
+// トークンの組織と権限は membership のもので、familyID が空の場合はログインとして新しいファミリーを作り、空でなければローテーションで引き継ぐ
+func issueTokens(refreshTokenRepo repository.RefreshTokenRepository, user *domain.User, membership *domain.Membership, familyID string) (string, string, error) {
+	token, claims, err := jwt.GenerateToken(user.ID, user.Email, string(membership.Role), membership.OrganizationID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, plaintext, err := domain.NewRefreshToken(user.ID, membership.OrganizationID, familyID, time.Now(), RefreshTokenTTL())
+	if err != nil {
+		return "", "", err
+	}
+	refreshToken.AccessTokenID = claims.ID
+	refreshToken.AccessTokenExpiresAt = claims.ExpiresAt.Time
+
+	err = refreshTokenRepo.Create(refreshToken)
+	if err != nil {
+		return "", "", err
+	}
+
+	return token, plaintext, nil
+}
@@ -0,0 +1,51 @@
+package usecase
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// AvailabilityUseCase 空き状況ユースケース
+type AvailabilityUseCase struct {
+	resourceRepo    repository.ResourceRepository
+	reservationRepo repository.ReservationRepository
+	blackoutRepo    repository.BlackoutRepository
+}
+
+// NewAvailabilityUseCase 空き状況ユースケースを作成
+func NewAvailabilityUseCase() *AvailabilityUseCase {
+	return &AvailabilityUseCase{
+		resourceRepo:    db.NewResourceRepository(),
+		reservationRepo: db.NewReservationRepository(),
+		blackoutRepo:    db.NewBlackoutRepository(),
+	}
+}
+
+// GetAvailability 期間内の全時間枠の定員・予約数・残り枠を取得
+func (uc *AvailabilityUseCase) GetAvailability(organizationID, resourceID uint, from, to time.Time) ([]*domain.DayAvailability, error) {
+	if err := domain.ValidateDateRange(from, to); err != nil {
+		return nil, err
+	}
+
+	resource, err := uc.resourceRepo.FindByID(organizationID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 期間の前後にはみ出す予約や、バッファだけが期間に掛かる予約も集計に含める
+	window := resource.BlockingWindow(domain.AvailabilityWindow(resource, from, to))
+	reservations, err := uc.reservationRepo.FindActiveByResourceAndPeriod(resource.OrganizationID, resource.ID, window.StartAt, window.EndAt)
+	if err != nil {
+		return nil, err
+	}
+
+	blackouts, err := uc.blackoutRepo.FindForResource(resource.OrganizationID, resource.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.ComputeAvailability(resource, from, to, reservations, blackouts)
+}
@@ -0,0 +1,193 @@
+package usecase
+
+import (
+	"io"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// 休業日ファイルの形式
+const (
+	HolidayFormatCSV = "csv"
+	HolidayFormatICS = "ics"
+)
+
+// BlackoutUseCase 休業日ユースケース
+type BlackoutUseCase struct {
+	blackoutRepo repository.BlackoutRepository
+	resourceRepo repository.ResourceRepository
+}
+
+// NewBlackoutUseCase 休業日ユースケースを作成
+func NewBlackoutUseCase() *BlackoutUseCase {
+	return &BlackoutUseCase{
+		blackoutRepo: db.NewBlackoutRepository(),
+		resourceRepo: db.NewResourceRepository(),
+	}
+}
+
+// BlackoutRequest 休業日作成・更新リクエスト
+// ResourceID を省略すると全リソース共通の休業日になる
+type BlackoutRequest struct {
+	ResourceID *uint     `json:"resource_id"`
+	Date       time.Time `json:"date"`
+	Reason     string    `json:"reason"`
+}
+
+// CreateBlackout 組織に休業日を作成
+func (uc *BlackoutUseCase) CreateBlackout(organizationID uint, req *BlackoutRequest) (*domain.BlackoutDate, error) {
+	if err := uc.ensureResource(organizationID, req.ResourceID); err != nil {
+		return nil, err
+	}
+
+	blackout, err := domain.NewBlackoutDate(req.ResourceID, req.Date, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+	blackout.OrganizationID = organizationID
+
+	err = uc.blackoutRepo.Create(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	return blackout, nil
+}
+
+// GetBlackout 休業日を取得
+func (uc *BlackoutUseCase) GetBlackout(organizationID, id uint) (*domain.BlackoutDate, error) {
+	return uc.blackoutRepo.FindByID(organizationID, id)
+}
+
+// ListBlackouts 組織の休業日一覧を取得
+func (uc *BlackoutUseCase) ListBlackouts(organizationID uint, resourceID *uint) ([]*domain.BlackoutDate, error) {
+	return uc.blackoutRepo.FindAll(organizationID, resourceID)
+}
+
+// UpdateBlackout 休業日を更新
+func (uc *BlackoutUseCase) UpdateBlackout(organizationID, id uint, req *BlackoutRequest) (*domain.BlackoutDate, error) {
+	blackout, err := uc.blackoutRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ensureResource(organizationID, req.ResourceID); err != nil {
+		return nil, err
+	}
+
+	err = blackout.Update(req.ResourceID, req.Date, req.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.blackoutRepo.Update(blackout)
+	if err != nil {
+		return nil, err
+	}
+
+	return blackout, nil
+}
+
+// DeleteBlackout 休業日を削除
+func (uc *BlackoutUseCase) DeleteBlackout(organizationID, id uint) error {
+	_, err := uc.blackoutRepo.FindByID(organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	return uc.blackoutRepo.Delete(organizationID, id)
+}
+
+// ImportHolidaysResponse 祝日インポート結果
+type ImportHolidaysResponse struct {
+	Imported []*domain.BlackoutDate `json:"imported"`
+	Skipped  int                    `json:"skipped"`
+}
+
+// ImportHolidays CSV・ICSファイルから祝日を休業日として取り込む
+// 同じ対象・日付の休業日が既にある場合はスキップするため、同じファイルを何度取り込んでもよい
+func (uc *BlackoutUseCase) ImportHolidays(organizationID uint, format string, r io.Reader, resourceID *uint) (*ImportHolidaysResponse, error) {
+	if err := uc.ensureResource(organizationID, resourceID); err != nil {
+		return nil, err
+	}
+
+	var (
+		parsed []*domain.BlackoutDate
+		err    error
+	)
+	switch format {
+	case HolidayFormatCSV:
+		parsed, err = domain.ParseHolidaysCSV(r, resourceID)
+	case HolidayFormatICS:
+		parsed, err = domain.ParseHolidaysICS(r, resourceID)
+	default:
+		return nil, domain.ErrInvalidHolidayFile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := uc.blackoutRepo.FindAll(organizationID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[string]bool)
+	for _, blackout := range existing {
+		if sameScope(blackout.ResourceID, resourceID) {
+			known[blackout.Date.Format("2006-01-02")] = true
+		}
+	}
+
+	resp := &ImportHolidaysResponse{
+		Imported: make([]*domain.BlackoutDate, 0, len(parsed)),
+	}
+	for _, blackout := range parsed {
+		key := blackout.Date.Format("2006-01-02")
+		if known[key] {
+			resp.Skipped++
+			continue
+		}
+		known[key] = true
+		blackout.OrganizationID = organizationID
+		resp.Imported = append(resp.Imported, blackout)
+	}
+
+	err = uc.blackoutRepo.CreateAll(resp.Imported)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (uc *BlackoutUseCase) ensureResource(organizationID uint, resourceID *uint) error {
+	if resourceID == nil {
+		return nil
+	}
+	_, err := uc.resourceRepo.FindByID(organizationID, *resourceID)
+	return err
+}
+
+func sameScope(a, b *uint) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+// ensureNotBlackedOut 時間枠がかかる日付にリソースの休業日がないかチェック
+func ensureNotBlackedOut(repo repository.BlackoutRepository, resource *domain.Resource, timeSlot *domain.TimeSlot) error {
+	dates := timeSlot.Dates()
+	blackouts, err := repo.FindForResource(resource.OrganizationID, resource.ID, dates[0], dates[len(dates)-1])
+	if err != nil {
+		return err
+	}
+	if blackouts.During(resource.ID, timeSlot) != nil {
+		return domain.ErrBlackoutDate
+	}
+	return nil
+}
@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"reservation-system/internal/domain"
+)
+
+// DefaultHoldTTL 仮予約の保持期限の既定値
+const DefaultHoldTTL = 15 * time.Minute
+
+// HoldTTL 環境変数 RESERVATION_HOLD_TTL_MINUTES から仮予約の保持期限を取得（0で無期限）
+func HoldTTL() time.Duration {
+	return minutesFromEnv("RESERVATION_HOLD_TTL_MINUTES", DefaultHoldTTL)
+}
+
+// DefaultNoShowGrace 無断キャンセルと判定するまでの猶予時間の既定値
+const DefaultNoShowGrace = 15 * time.Minute
+
+// NoShowGrace 環境変数 NO_SHOW_GRACE_MINUTES から開始後のチェックイン猶予時間を取得
+func NoShowGrace() time.Duration {
+	return minutesFromEnv("NO_SHOW_GRACE_MINUTES", DefaultNoShowGrace)
+}
+
+// DefaultRefreshTokenTTL リフレッシュトークンの有効期限の既定値
+const DefaultRefreshTokenTTL = 30 * 24 * time.Hour
+
+// RefreshTokenTTL 環境変数 REFRESH_TOKEN_TTL_HOURS からリフレッシュトークンの有効期限を取得
+func RefreshTokenTTL() time.Duration {
+	ttl := durationFromEnv("REFRESH_TOKEN_TTL_HOURS", time.Hour, DefaultRefreshTokenTTL)
+	if ttl == 0 {
+		return DefaultRefreshTokenTTL
+	}
+	return ttl
+}
+
+// DefaultPasswordResetTTL パスワード再設定トークンの有効期限の既定値
+const DefaultPasswordResetTTL = time.Hour
+
+// PasswordResetTTL 環境変数 PASSWORD_RESET_TTL_MINUTES からパスワード再設定トークンの有効期限を取得
+func PasswordResetTTL() time.Duration {
+	ttl := minutesFromEnv("PASSWORD_RESET_TTL_MINUTES", DefaultPasswordResetTTL)
+	if ttl == 0 {
+		return DefaultPasswordResetTTL
+	}
+	return ttl
+}
+
+// DefaultEmailVerificationTTL メールアドレス確認トークンの有効期限の既定値
+const DefaultEmailVerificationTTL = 24 * time.Hour
+
+// EmailVerificationTTL 環境変数 EMAIL_VERIFICATION_TTL_HOURS からメールアドレス確認トークンの有効期限を取得
+func EmailVerificationTTL() time.Duration {
+	ttl := durationFromEnv("EMAIL_VERIFICATION_TTL_HOURS", time.Hour, DefaultEmailVerificationTTL)
+	if ttl == 0 {
+		return DefaultEmailVerificationTTL
+	}
+	return ttl
+}
+
+// 確認メールの再送制限の既定値
+const (
+	DefaultVerificationResendInterval   = time.Minute
+	DefaultVerificationResendDailyLimit = 5
+)
+
+// VerificationResendThrottle 環境変数 EMAIL_VERIFICATION_RESEND_INTERVAL_SECONDS と
+// EMAIL_VERIFICATION_DAILY_LIMIT から確認メールの再送制限を取得（1日の上限は0で無制限）
+func VerificationResendThrottle() domain.ResendThrottle {
+	return domain.ResendThrottle{
+		Interval:   durationFromEnv("EMAIL_VERIFICATION_RESEND_INTERVAL_SECONDS", time.Second, DefaultVerificationResendInterval),
+		DailyLimit: intFromEnv("EMAIL_VERIFICATION_DAILY_LIMIT", DefaultVerificationResendDailyLimit),
+	}
+}
+
+// DefaultMFAChallengeTTL ログイン時の MFA チャレンジの有効期限の既定値
+const DefaultMFAChallengeTTL = 5 * time.Minute
+
+// MFAChallengeTTL 環境変数 MFA_CHALLENGE_TTL_MINUTES から MFA チャレンジの有効期限を取得
+func MFAChallengeTTL() time.Duration {
+	ttl := minutesFromEnv("MFA_CHALLENGE_TTL_MINUTES", DefaultMFAChallengeTTL)
+	if ttl == 0 {
+		return DefaultMFAChallengeTTL
+	}
+	return ttl
+}
+
+// DefaultTOTPIssuer 認証アプリに表示する発行者名の既定値
+const DefaultTOTPIssuer = "Reservation System"
+
+// TOTPIssuer 環境変数 TOTP_ISSUER から認証アプリに表示する発行者名を取得
+func TOTPIssuer() string {
+	if issuer := os.Getenv("TOTP_ISSUER"); issuer != "" {
+		return issuer
+	}
+	return DefaultTOTPIssuer
+}
+
+// ログインのロックアウトの既定値
+// アカウントは3回まで待ち時間なしで失敗でき、10回でロックアウトする
+// IP アドレスは複数のユーザーが共有することがあるため、上限を大きくする
+const (
+	DefaultLoginMaxAttempts   = 10
+	DefaultLoginLockout       = 15 * time.Minute
+	DefaultLoginIPMaxAttempts = 50
+	DefaultLoginIPLockout     = time.Hour
+	loginFreeAttempts         = 3
+	loginIPFreeAttempts       = 10
+	loginBaseDelay            = time.Second
+)
+
+// AccountLockoutPolicy 環境変数 LOGIN_MAX_ATTEMPTS と LOGIN_LOCKOUT_MINUTES からアカウントのロックアウト設定を取得
+func AccountLockoutPolicy() domain.LockoutPolicy {
+	return lockoutPolicy("LOGIN_MAX_ATTEMPTS", DefaultLoginMaxAttempts, "LOGIN_LOCKOUT_MINUTES", DefaultLoginLockout, loginFreeAttempts)
+}
+
+// IPLockoutPolicy 環境変数 LOGIN_IP_MAX_ATTEMPTS と LOGIN_IP_LOCKOUT_MINUTES から IP アドレスのロックアウト設定を取得
+func IPLockoutPolicy() domain.LockoutPolicy {
+	return lockoutPolicy("LOGIN_IP_MAX_ATTEMPTS", DefaultLoginIPMaxAttempts, "LOGIN_IP_LOCKOUT_MINUTES", DefaultLoginIPLockout, loginIPFreeAttempts)
+}
+
+func lockoutPolicy(maxAttemptsKey string, defaultMaxAttempts int, lockoutKey string, defaultLockout time.Duration, freeAttempts int) domain.LockoutPolicy {
+	maxAttempts := intFromEnv(maxAttemptsKey, defaultMaxAttempts)
+	if maxAttempts == 0 {
+		maxAttempts = defaultMaxAttempts
+	}
+	lockout := minutesFromEnv(lockoutKey, defaultLockout)
+	if lockout == 0 {
+		lockout = defaultLockout
+	}
+	if freeAttempts >= maxAttempts {
+		freeAttempts = maxAttempts - 1
+	}
+
+	return domain.LockoutPolicy{
+		FreeAttempts:    freeAttempts,
+		BaseDelay:       loginBaseDelay,
+		MaxAttempts:     maxAttempts,
+		LockoutDuration: lockout,
+	}
+}
+
+// isBootstrapAdmin 環境変数 ADMIN_EMAILS（カンマ区切り）に含まれるメールアドレスかチェック
+// 含まれるユーザーはメールアドレスの確認時に既定の組織の admin 権限になる
+func isBootstrapAdmin(email string) bool {
+	for _, admin := range strings.Split(os.Getenv("ADMIN_EMAILS"), ",") {
+		admin = strings.TrimSpace(admin)
+		if admin != "" && strings.EqualFold(admin, email) {
+			return true
+		}
+	}
+	return false
+}
+
+func minutesFromEnv(key string, defaultValue time.Duration) time.Duration {
+	return durationFromEnv(key, time.Minute, defaultValue)
+}
+
+// durationFromEnv 環境変数の整数値を unit 単位の期間として取得（未設定・不正な値は defaultValue）
+func durationFromEnv(key string, unit, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("invalid %s=%q, using default %s", key, value, defaultValue)
+		return defaultValue
+	}
+	return time.Duration(n) * unit
+}
+
+// intFromEnv 環境変数の0以上の整数値を取得（未設定・不正な値は defaultValue）
+func intFromEnv(key string, defaultValue int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		log.Printf("invalid %s=%q, using default %d", key, value, defaultValue)
+		return defaultValue
+	}
+	return n
+}
@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/infrastructure/mail"
+	"reservation-system/internal/repository"
+)
+
+// EmailVerificationUseCase メールアドレス確認ユースケース
+type EmailVerificationUseCase struct {
+	userRepo              repository.UserRepository
+	organizationRepo      repository.OrganizationRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mailSender            mail.Sender
+	verificationTTL       time.Duration
+	resendThrottle        domain.ResendThrottle
+}
+
+// NewEmailVerificationUseCase メールアドレス確認ユースケースを作成
+func NewEmailVerificationUseCase() *EmailVerificationUseCase {
+	return &EmailVerificationUseCase{
+		userRepo:              db.NewUserRepository(),
+		organizationRepo:      db.NewOrganizationRepository(),
+		emailVerificationRepo: db.NewEmailVerificationRepository(),
+		mailSender:            mail.NewSender(),
+		verificationTTL:       EmailVerificationTTL(),
+		resendThrottle:        VerificationResendThrottle(),
+	}
+}
+
+// VerifyEmailRequest メールアドレス確認リクエスト
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// Verify トークンを使用済みにしてユーザーのメールアドレスを確認済みにする
+// ADMIN_EMAILS の管理者権限は、登録した人がメールアドレスの持ち主だと確認できたこの時点で付与する
+func (uc *EmailVerificationUseCase) Verify(req *VerifyEmailRequest) (*domain.User, error) {
+	stored, err := uc.emailVerificationRepo.FindByHash(domain.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = stored.Use(now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じトークンで同時に確認された場合、成功するのは1リクエストのみ
+	used, err := uc.emailVerificationRepo.MarkUsed(stored)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	user, err := uc.userRepo.FindByID(stored.UserID)
+	if err != nil {
+		return nil, domain.ErrInvalidVerificationToken
+	}
+
+	err = user.VerifyEmail(now)
+	if err == domain.ErrEmailAlreadyVerified {
+		return user, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// 同時に確認された場合は先に保存した側が以降の処理を行う
+	verified, err := uc.userRepo.MarkEmailVerified(user)
+	if err != nil {
+		return nil, err
+	}
+	if !verified {
+		return user, nil
+	}
+
+	err = uc.emailVerificationRepo.InvalidateByUserID(user.ID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	err = grantBootstrapAdmin(uc.organizationRepo, user)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// Resend 確認メールを再送する
+// 以前に送ったトークンは使えなくなり、送信間隔と1日の送信数が制限を超える場合は ErrVerificationThrottled
+func (uc *EmailVerificationUseCase) Resend(principal *domain.Principal) error {
+	user, err := uc.userRepo.FindByID(principal.UserID)
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return domain.ErrEmailAlreadyVerified
+	}
+
+	now := time.Now()
+	recent, err := uc.emailVerificationRepo.FindCreatedSince(user.ID, now.Add(-24*time.Hour))
+	if err != nil {
+		return err
+	}
+
+	sentAt := make([]time.Time, 0, len(recent))
+	for _, token := range recent {
+		sentAt = append(sentAt, token.CreatedAt)
+	}
+	err = uc.resendThrottle.Allow(sentAt, now)
+	if err != nil {
+		return err
+	}
+
+	err = uc.emailVerificationRepo.InvalidateByUserID(user.ID, now)
+	if err != nil {
+		return err
+	}
+
+	return sendVerification(uc.emailVerificationRepo, uc.mailSender, user, now, uc.verificationTTL)
+}
+
+// sendVerification 確認トークンを発行して確認メールを送る
+// 登録や再送の要求自体は成功させるため、メールの送信失敗はログに残すだけでエラーにしない
+func sendVerification(emailVerificationRepo repository.EmailVerificationRepository, mailSender mail.Sender, user *domain.User, now time.Time, ttl time.Duration) error {
+	token, plaintext, err := domain.NewEmailVerificationToken(user.ID, now, ttl)
+	if err != nil {
+		return err
+	}
+
+	err = emailVerificationRepo.Create(token)
+	if err != nil {
+		return err
+	}
+
+	if err := mailSender.Send(emailVerificationMessage(user, plaintext, ttl)); err != nil {
+		log.Printf("failed to send verification mail to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// emailVerificationMessage 確認メールを作成
+// 環境変数 EMAIL_VERIFICATION_URL が設定されていればトークンを付けたリンクを、なければトークンそのものを記載する
+func emailVerificationMessage(user *domain.User, token string, ttl time.Duration) *mail.Message {
+	instruction := "Verification token: " + token
+	if verificationURL := os.Getenv("EMAIL_VERIFICATION_URL"); verificationURL != "" {
+		instruction = verificationURL + "?token=" + url.QueryEscape(token)
+	}
+
+	return &mail.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nPlease confirm your email address to start making reservations.\n\n%s\n\n"+
+				"This expires in %s and can be used once. If you did not create an account, you can ignore this email.\n",
+			user.Name, instruction, ttl,
+		),
+	}
+}
+
+// ensureEmailVerified ユーザーがメールアドレスを確認済みかチェック（未確認なら ErrEmailNotVerified）
+func ensureEmailVerified(userRepo repository.UserRepository, userID uint) error {
+	user, err := userRepo.FindByID(userID)
+	if err != nil {
+		return err
+	}
+	if !user.IsEmailVerified() {
+		return domain.ErrEmailNotVerified
+	}
+	return nil
+}
@@ -0,0 +1,116 @@
+package usecase
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// loginGuard ログイン時のパスワード確認と総当たり攻撃の防止
+// アカウントごとの失敗回数はユーザーに、IP アドレスごとの失敗は記録として保存し、
+// それぞれのポリシーに従って待ち時間とロックアウトを適用する
+type loginGuard struct {
+	userRepo         repository.UserRepository
+	loginFailureRepo repository.LoginFailureRepository
+	accountPolicy    domain.LockoutPolicy
+	ipPolicy         domain.LockoutPolicy
+}
+
+func newLoginGuard() *loginGuard {
+	return &loginGuard{
+		userRepo:         db.NewUserRepository(),
+		loginFailureRepo: db.NewLoginFailureRepository(),
+		accountPolicy:    AccountLockoutPolicy(),
+		ipPolicy:         IPLockoutPolicy(),
+	}
+}
+
+// checkCredentials メールアドレスとパスワードを確認してユーザーを返す
+// IP アドレスからの失敗が多すぎる場合は ErrTooManyLoginAttempts、アカウントがロック中なら ErrAccountLocked
+// 失敗回数はログインが完了したとき（2段階認証が有効なユーザーは認証コードの確認後）に succeed でリセットする
+func (g *loginGuard) checkCredentials(email, password, ipAddress string) (*domain.User, error) {
+	now := time.Now()
+	if err := g.checkIP(ipAddress, now); err != nil {
+		return nil, err
+	}
+
+	user, err := g.userRepo.FindByEmail(email)
+	if err == domain.ErrUserNotFound {
+		return nil, g.fail(nil, ipAddress, now, domain.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// ロック中はパスワードを確認せず、失敗回数も増やさない
+	if user.IsLocked(now) {
+		return nil, g.fail(nil, ipAddress, now, domain.ErrAccountLocked)
+	}
+
+	if err := user.CheckPassword(password); err != nil {
+		return nil, g.fail(user, ipAddress, now, domain.ErrInvalidCredentials)
+	}
+
+	return user, nil
+}
+
+// checkIP IP アドレスからの失敗が多すぎる場合は ErrTooManyLoginAttempts
+func (g *loginGuard) checkIP(ipAddress string, now time.Time) error {
+	if ipAddress == "" {
+		return nil
+	}
+
+	// ロックアウト期間より前の失敗は数えない
+	failures, last, err := g.loginFailureRepo.CountSince(ipAddress, now.Add(-g.ipPolicy.LockoutDuration))
+	if err != nil {
+		return err
+	}
+	if now.Before(g.ipPolicy.LockedUntil(failures, last)) {
+		return domain.ErrTooManyLoginAttempts
+	}
+	return nil
+}
+
+// succeed ログインの完了時にアカウントの失敗回数とロックをリセット
+func (g *loginGuard) succeed(user *domain.User) error {
+	if user.FailedAttempts == 0 && user.LockedUntil == nil {
+		return nil
+	}
+	user.ClearFailedLogins()
+	return g.userRepo.UpdateLockout(user)
+}
+
+// fail ログインの失敗を IP アドレスと（user が nil でなければ）アカウントに記録して reason を返す
+func (g *loginGuard) fail(user *domain.User, ipAddress string, now time.Time, reason error) error {
+	if ipAddress != "" {
+		err := g.loginFailureRepo.Create(&domain.LoginFailure{IPAddress: ipAddress, CreatedAt: now})
+		if err != nil {
+			return err
+		}
+	}
+
+	if user == nil {
+		return reason
+	}
+
+	failures, err := g.userRepo.IncrementFailedAttempts(user.ID)
+	if err != nil {
+		return err
+	}
+
+	user.RecordFailedLogin(failures, g.accountPolicy, now)
+	if user.LockedUntil == nil {
+		return reason
+	}
+	if err := g.userRepo.UpdateLockout(user); err != nil {
+		return err
+	}
+	return reason
+}
+
+// purgeLoginFailures IP アドレスのロックアウト期間を過ぎたログイン失敗の記録を削除
+func (g *loginGuard) purgeLoginFailures(now time.Time) error {
+	return g.loginFailureRepo.DeleteBefore(now.Add(-g.ipPolicy.LockoutDuration))
+}
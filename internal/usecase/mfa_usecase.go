@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// MFAUseCase 2段階認証（TOTP）ユースケース
+type MFAUseCase struct {
+	userRepo         repository.UserRepository
+	organizationRepo repository.OrganizationRepository
+	refreshTokenRepo repository.RefreshTokenRepository
+	mfaChallengeRepo repository.MFAChallengeRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+	loginGuard       *loginGuard
+	issuer           string
+}
+
+// NewMFAUseCase 2段階認証ユースケースを作成
+func NewMFAUseCase() *MFAUseCase {
+	return &MFAUseCase{
+		userRepo:         db.NewUserRepository(),
+		organizationRepo: db.NewOrganizationRepository(),
+		refreshTokenRepo: db.NewRefreshTokenRepository(),
+		mfaChallengeRepo: db.NewMFAChallengeRepository(),
+		recoveryCodeRepo: db.NewRecoveryCodeRepository(),
+		loginGuard:       newLoginGuard(),
+		issuer:           TOTPIssuer(),
+	}
+}
+
+// TOTPEnrollmentResponse 認証アプリに登録する秘密鍵
+type TOTPEnrollmentResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+// EnrollTOTP 新しい秘密鍵を発行して TOTP の登録を開始する
+// 確認が済むまでは何度でもやり直せ、そのたびに以前の秘密鍵は破棄される
+func (uc *MFAUseCase) EnrollTOTP(principal *domain.Principal) (*TOTPEnrollmentResponse, error) {
+	user, err := uc.userRepo.FindByID(principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := domain.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.BeginTOTPEnrollment(secret)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.userRepo.UpdateTOTP(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollmentResponse{
+		Secret:          secret,
+		ProvisioningURI: domain.TOTPProvisioningURI(uc.issuer, user.Email, secret),
+	}, nil
+}
+
+// TOTPCodeRequest 認証コードのリクエスト（TOTP のコード、または無効化ではリカバリーコードも可）
+type TOTPCodeRequest struct {
+	Code string `json:"code"`
+}
+
+// RecoveryCodesResponse 発行したリカバリーコード（平文を返すのはこの一度だけ）
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ConfirmTOTP 認証アプリのコードで登録を確認して2段階認証を有効にし、リカバリーコードを発行する
+func (uc *MFAUseCase) ConfirmTOTP(principal *domain.Principal, req *TOTPCodeRequest) (*RecoveryCodesResponse, error) {
+	user, err := uc.userRepo.FindByID(principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ConfirmTOTP(req.Code, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	codes, plaintexts, err := domain.NewRecoveryCodes(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 有効化に失敗しても、未使用のリカバリーコードが残るだけで次の確認で入れ替わる
+	err = uc.recoveryCodeRepo.ReplaceForUser(user.ID, codes)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じコードで同時に確認された場合や、確認中に登録をやり直した場合は有効にしない
+	enabled, err := uc.userRepo.EnableTOTP(user)
+	if err != nil {
+		return nil, err
+	}
+	if !enabled {
+		return nil, domain.ErrInvalidMFACode
+	}
+
+	return &RecoveryCodesResponse{RecoveryCodes: plaintexts}, nil
+}
+
+// DisableTOTP TOTP またはリカバリーコードを確認して2段階認証を無効にし、リカバリーコードを破棄する
+func (uc *MFAUseCase) DisableTOTP(principal *domain.Principal, req *TOTPCodeRequest) error {
+	user, err := uc.userRepo.FindByID(principal.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.IsTOTPEnabled() {
+		return domain.ErrTOTPNotEnabled
+	}
+
+	err = uc.verifyCode(user, req.Code, time.Now())
+	if err != nil {
+		return err
+	}
+
+	user.DisableTOTP()
+	err = uc.userRepo.UpdateTOTP(user)
+	if err != nil {
+		return err
+	}
+
+	return uc.recoveryCodeRepo.DeleteByUserID(user.ID)
+}
+
+// VerifyMFARequest MFA チャレンジの認証リクエスト
+// IPAddress はハンドラーが設定する接続元で、IP アドレスごとの失敗回数の記録に使う
+type VerifyMFARequest struct {
+	MFAToken  string `json:"mfa_token"`
+	Code      string `json:"code"`
+	IPAddress string `json:"-"`
+}
+
+// VerifyChallenge ログイン時に返した MFA チャレンジを TOTP またはリカバリーコードで認証し、トークンを発行する
+// コードを確認できるのは1つのチャレンジにつき MaxMFAAttempts 回までで、使い切るとパスワードからやり直す必要がある
+// 誤ったコードはパスワードの誤りと同じくアカウントと IP アドレスの失敗回数に数え、ロック中は認証しない
+func (uc *MFAUseCase) VerifyChallenge(req *VerifyMFARequest) (*AuthResponse, error) {
+	challenge, err := uc.mfaChallengeRepo.FindByHash(domain.HashToken(req.MFAToken))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	err = challenge.Valid(now)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(challenge.UserID)
+	if err != nil {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	err = uc.loginGuard.checkIP(req.IPAddress, now)
+	if err != nil {
+		return nil, err
+	}
+	if user.IsLocked(now) {
+		return nil, uc.loginGuard.fail(nil, req.IPAddress, now, domain.ErrAccountLocked)
+	}
+
+	// コードを確認する前に確認回数を予約し、同時に送られたコードも上限までしか確認しない
+	reserved, err := uc.mfaChallengeRepo.ReserveAttempt(challenge)
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	err = uc.verifyCode(user, req.Code, now)
+	if err == domain.ErrInvalidMFACode {
+		return nil, uc.loginGuard.fail(user, req.IPAddress, now, domain.ErrInvalidMFACode)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = challenge.Use(now)
+	if err != nil {
+		return nil, err
+	}
+
+	// 同じチャレンジで同時に認証された場合、成功するのは1リクエストのみ
+	used, err := uc.mfaChallengeRepo.MarkUsed(challenge)
+	if err != nil {
+		return nil, err
+	}
+	if !used {
+		return nil, domain.ErrInvalidMFAChallenge
+	}
+
+	// チャレンジの発行後に組織から外された場合はログインさせない
+	organization, membership, err := selectOrganization(uc.organizationRepo, user.ID, challenge.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.loginGuard.succeed(user)
+	if err != nil {
+		return nil, err
+	}
+
+	token, refreshToken, err := issueTokens(uc.refreshTokenRepo, user, membership, "")
+	if err != nil {
+		return nil, err
+	}
+
+	return &AuthResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+		Organization: organization,
+		User:         newMemberResponse(user, membership),
+	}, nil
+}
+
+// verifyCode TOTP のコード、一致しなければリカバリーコードとして検証する
+// TOTP は使った時間ステップを、リカバリーコードは使用済みを保存して再利用を防ぐ
+func (uc *MFAUseCase) verifyCode(user *domain.User, code string, now time.Time) error {
+	err := user.VerifyTOTP(code, now)
+	if err == nil {
+		// 同じコードで同時に認証された場合、成功するのは1リクエストのみ
+		used, err := uc.userRepo.UseTOTPStep(user)
+		if err != nil {
+			return err
+		}
+		if !used {
+			return domain.ErrInvalidMFACode
+		}
+		return nil
+	}
+	if err != domain.ErrInvalidMFACode {
+		return err
+	}
+
+	recoveryCode, err := uc.recoveryCodeRepo.FindUnused(user.ID, domain.HashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+
+	used, err := uc.recoveryCodeRepo.MarkUsed(recoveryCode, now)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidMFACode
+	}
+	return nil
+}
+
+// startMFAChallenge パスワードを確認したユーザーに MFA チャレンジを発行し、平文のチャレンジトークンを返す
+func startMFAChallenge(mfaChallengeRepo repository.MFAChallengeRepository, userID, organizationID uint) (string, error) {
+	challenge, plaintext, err := domain.NewMFAChallenge(userID, organizationID, time.Now(), MFAChallengeTTL())
+	if err != nil {
+		return "", err
+	}
+
+	err = mfaChallengeRepo.Create(challenge)
+	if err != nil {
+		return "", err
+	}
+
+	return plaintext, nil
+}
@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// OrganizationUseCase 組織ユースケース
+type OrganizationUseCase struct {
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+}
+
+// NewOrganizationUseCase 組織ユースケースを作成
+func NewOrganizationUseCase() *OrganizationUseCase {
+	return &OrganizationUseCase{
+		organizationRepo: db.NewOrganizationRepository(),
+		userRepo:         db.NewUserRepository(),
+	}
+}
+
+// CreateOrganizationRequest 組織作成リクエスト
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+	Slug string `json:"slug"`
+}
+
+// CreateOrganization 組織を作成し、作成した管理者をその組織の管理者として所属させる
+func (uc *OrganizationUseCase) CreateOrganization(req *CreateOrganizationRequest, principal *domain.Principal) (*domain.Organization, error) {
+	organization, err := domain.NewOrganization(req.Name, req.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.organizationRepo.Create(organization)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := domain.NewMembership(organization.ID, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = membership.SetRole(domain.RoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.organizationRepo.AddMember(membership)
+	if err != nil {
+		return nil, err
+	}
+
+	return organization, nil
+}
+
+// ListOrganizations 操作主体が所属する組織の一覧を取得
+func (uc *OrganizationUseCase) ListOrganizations(principal *domain.Principal) ([]*domain.Organization, error) {
+	return uc.organizationRepo.FindByUserID(principal.UserID)
+}
+
+// AddMemberRequest 組織へのメンバー追加リクエスト
+type AddMemberRequest struct {
+	UserID uint `json:"user_id"`
+}
+
+// AddMember 操作主体の組織にユーザーを一般利用者として追加（所属済みの場合は現在の権限のまま）
+func (uc *OrganizationUseCase) AddMember(req *AddMemberRequest, principal *domain.Principal) (*domain.Membership, error) {
+	_, err := uc.userRepo.FindByID(req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := domain.NewMembership(principal.OrganizationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.organizationRepo.AddMember(membership)
+	if err != nil {
+		return nil, err
+	}
+
+	return membership, nil
+}
+
+// RemoveMember 操作主体の組織からユーザーを外す
+// 発行済みのトークンは有効期限まで使えるため、外したユーザーのアクセスは次回ログインから拒否される
+func (uc *OrganizationUseCase) RemoveMember(userID uint, principal *domain.Principal) error {
+	err := ensureMember(uc.organizationRepo, principal.OrganizationID, userID)
+	if err != nil {
+		return err
+	}
+
+	return uc.organizationRepo.RemoveMember(principal.OrganizationID, userID)
+}
+
+// ensureMember ユーザーが組織に所属しているかチェック
+// 他の組織のユーザーは存在しないものとして ErrUserNotFound を返す
+func ensureMember(organizationRepo repository.OrganizationRepository, organizationID, userID uint) error {
+	member, err := organizationRepo.IsMember(organizationID, userID)
+	if err != nil {
+		return err
+	}
+	if !member {
+		return domain.ErrUserNotFound
+	}
+	return nil
+}
+
+// joinDefaultOrganization 新規登録したユーザーを既定の組織に一般利用者として所属させる
+func joinDefaultOrganization(organizationRepo repository.OrganizationRepository, user *domain.User) (*domain.Organization, *domain.Membership, error) {
+	organization, err := organizationRepo.FindBySlug(domain.DefaultOrganizationSlug)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	membership, err := domain.NewMembership(organization.ID, user.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = organizationRepo.AddMember(membership)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return organization, membership, nil
+}
+
+// selectOrganization ログイン先の組織と、その組織での所属（権限）を決定
+// organizationID を指定した場合はその組織への所属を確認し、省略時は最初に所属した組織を選ぶ
+func selectOrganization(organizationRepo repository.OrganizationRepository, userID, organizationID uint) (*domain.Organization, *domain.Membership, error) {
+	organizations, err := organizationRepo.FindByUserID(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, organization := range organizations {
+		if organizationID == 0 || organization.ID == organizationID {
+			membership, err := organizationRepo.FindMembership(organization.ID, userID)
+			if err != nil {
+				return nil, nil, err
+			}
+			return organization, membership, nil
+		}
+	}
+	return nil, nil, domain.ErrNotOrganizationMember
+}
+
+// grantBootstrapAdmin ADMIN_EMAILS に含まれるユーザーを既定の組織の管理者にする
+// 既定の組織から外されている場合は何もしない
+func grantBootstrapAdmin(organizationRepo repository.OrganizationRepository, user *domain.User) error {
+	if !isBootstrapAdmin(user.Email) {
+		return nil
+	}
+
+	organization, err := organizationRepo.FindBySlug(domain.DefaultOrganizationSlug)
+	if err != nil {
+		return err
+	}
+
+	membership, err := organizationRepo.FindMembership(organization.ID, user.ID)
+	if err == domain.ErrNotOrganizationMember {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	err = membership.SetRole(domain.RoleAdmin)
+	if err != nil {
+		return err
+	}
+	return organizationRepo.UpdateMemberRole(membership)
+}
+
+// findMembership 組織での所属を取得（他の組織のユーザーは存在しないものとして ErrUserNotFound）
+func findMembership(organizationRepo repository.OrganizationRepository, organizationID, userID uint) (*domain.Membership, error) {
+	membership, err := organizationRepo.FindMembership(organizationID, userID)
+	if err == domain.ErrNotOrganizationMember {
+		return nil, domain.ErrUserNotFound
+	}
+	return membership, err
+}
+
+// MemberResponse 組織のメンバーとしてのユーザー
+// 権限は組織ごとに持つため、Role には参照した組織での権限を設定する
+type MemberResponse struct {
+	*domain.User
+	Role domain.Role `json:"role"`
+}
+
+// newMemberResponse ログインした本人に返すユーザー情報
+func newMemberResponse(user *domain.User, membership *domain.Membership) *MemberResponse {
+	return &MemberResponse{
+		User: &domain.User{
+			ID:              user.ID,
+			Email:           user.Email,
+			Name:            user.Name,
+			EmailVerifiedAt: user.EmailVerifiedAt,
+			TOTPEnabledAt:   user.TOTPEnabledAt,
+			CreatedAt:       user.CreatedAt,
+			UpdatedAt:       user.UpdatedAt,
+		},
+		Role: membership.Role,
+	}
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/infrastructure/mail"
+	"reservation-system/internal/repository"
+)
+
+// PasswordResetUseCase パスワード再設定ユースケース
+type PasswordResetUseCase struct {
+	userRepo          repository.UserRepository
+	passwordResetRepo repository.PasswordResetRepository
+	refreshTokenRepo  repository.RefreshTokenRepository
+	revokedTokenRepo  repository.RevokedTokenRepository
+	mailSender        mail.Sender
+	resetTTL          time.Duration
+}
+
+// NewPasswordResetUseCase パスワード再設定ユースケースを作成
+func NewPasswordResetUseCase() *PasswordResetUseCase {
+	return &PasswordResetUseCase{
+		userRepo:          db.NewUserRepository(),
+		passwordResetRepo: db.NewPasswordResetRepository(),
+		refreshTokenRepo:  db.NewRefreshTokenRepository(),
+		revokedTokenRepo:  db.NewRevokedTokenRepository(),
+		mailSender:        mail.NewSender(),
+		resetTTL:          PasswordResetTTL(),
+	}
+}
+
+// ForgotPasswordRequest パスワード再設定メールの送信リクエスト
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// RequestReset パスワード再設定トークンを発行してメールで送る
+// 登録の有無を推測させないため、未登録のメールアドレスやメールの送信失敗でもエラーにしない
+func (uc *PasswordResetUseCase) RequestReset(req *ForgotPasswordRequest) error {
+	user, err := uc.userRepo.FindByEmail(req.Email)
+	if err == domain.ErrUserNotFound {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	// 以前に発行したトークンは使えなくする
+	now := time.Now()
+	err = uc.passwordResetRepo.InvalidateByUserID(user.ID, now)
+	if err != nil {
+		return err
+	}
+
+	token, plaintext, err := domain.NewPasswordResetToken(user.ID, now, uc.resetTTL)
+	if err != nil {
+		return err
+	}
+
+	err = uc.passwordResetRepo.Create(token)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.mailSender.Send(passwordResetMessage(user, plaintext, uc.resetTTL)); err != nil {
+		log.Printf("failed to send password reset mail to user %d: %v", user.ID, err)
+	}
+	return nil
+}
+
+// ResetPasswordRequest パスワード再設定リクエスト
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPassword トークンを使用済みにしてパスワードを変更し、既存のセッションをすべて終了させる
+func (uc *PasswordResetUseCase) ResetPassword(req *ResetPasswordRequest) error {
+	stored, err := uc.passwordResetRepo.FindByHash(domain.HashToken(req.Token))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = stored.Use(now)
+	if err != nil {
+		return err
+	}
+
+	// 同じトークンで同時に再設定された場合、成功するのは1リクエストのみ
+	used, err := uc.passwordResetRepo.MarkUsed(stored)
+	if err != nil {
+		return err
+	}
+	if !used {
+		return domain.ErrInvalidResetToken
+	}
+
+	user, err := uc.userRepo.FindByID(stored.UserID)
+	if err != nil {
+		return domain.ErrInvalidResetToken
+	}
+
+	err = user.SetPassword(req.Password)
+	if err != nil {
+		return err
+	}
+
+	// 同時に進むログインや2段階認証の更新を上書きしないよう、変更した列だけを保存する
+	err = uc.userRepo.UpdatePassword(user)
+	if err != nil {
+		return err
+	}
+
+	// メールアドレスの持ち主であることを確認できたのでロックも解除する
+	user.ClearFailedLogins()
+	err = uc.userRepo.UpdateLockout(user)
+	if err != nil {
+		return err
+	}
+
+	err = uc.passwordResetRepo.InvalidateByUserID(user.ID, now)
+	if err != nil {
+		return err
+	}
+
+	return endSessions(uc.refreshTokenRepo, uc.revokedTokenRepo, user.ID, now)
+}
+
+// passwordResetMessage パスワード再設定メールを作成
+// 環境変数 PASSWORD_RESET_URL が設定されていればトークンを付けたリンクを、なければトークンそのものを記載する
+func passwordResetMessage(user *domain.User, token string, ttl time.Duration) *mail.Message {
+	instruction := "Reset token: " + token
+	if resetURL := os.Getenv("PASSWORD_RESET_URL"); resetURL != "" {
+		instruction = resetURL + "?token=" + url.QueryEscape(token)
+	}
+
+	return &mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hello %s,\n\nA password reset was requested for your account.\n\n%s\n\n"+
+				"This expires in %s and can be used once. If you did not request a reset, you can ignore this email.\n",
+			user.Name, instruction, ttl,
+		),
+	}
+}
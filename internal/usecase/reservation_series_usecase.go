@@ -0,0 +1,241 @@
+package usecase
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// ReservationSeriesUseCase 繰り返し予約ユースケース
+type ReservationSeriesUseCase struct {
+	seriesRepo       repository.ReservationSeriesRepository
+	reservationRepo  repository.ReservationRepository
+	resourceRepo     repository.ResourceRepository
+	waitlistRepo     repository.WaitlistRepository
+	blackoutRepo     repository.BlackoutRepository
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+}
+
+// NewReservationSeriesUseCase 繰り返し予約ユースケースを作成
+func NewReservationSeriesUseCase() *ReservationSeriesUseCase {
+	return &ReservationSeriesUseCase{
+		seriesRepo:       db.NewReservationSeriesRepository(),
+		reservationRepo:  db.NewReservationRepository(),
+		resourceRepo:     db.NewResourceRepository(),
+		waitlistRepo:     db.NewWaitlistRepository(),
+		blackoutRepo:     db.NewBlackoutRepository(),
+		organizationRepo: db.NewOrganizationRepository(),
+		userRepo:         db.NewUserRepository(),
+	}
+}
+
+// CreateSeriesRequest 繰り返し予約作成リクエスト
+type CreateSeriesRequest struct {
+	UserID     uint      `json:"user_id"`
+	ResourceID uint      `json:"resource_id"`
+	StartDate  time.Time `json:"start_date"`
+	StartTime  string    `json:"start_time"`
+	EndTime    string    `json:"end_time"`
+	Quantity   int       `json:"quantity"`
+	RRule      string    `json:"rrule"`
+}
+
+// OccurrenceFailure 予約できなかった回とその理由
+type OccurrenceFailure struct {
+	Date   string `json:"date"`
+	Reason string `json:"reason"`
+}
+
+// CreateSeriesResponse 繰り返し予約作成レスポンス
+type CreateSeriesResponse struct {
+	Series       *domain.ReservationSeries `json:"series"`
+	Reservations []*domain.Reservation     `json:"reservations"`
+	Failures     []*OccurrenceFailure      `json:"failures"`
+}
+
+// CreateSeries RRULEから各回の予約を作成
+// 定員超過やスケジュール外の回はスキップし、レスポンスの failures で報告する
+func (uc *ReservationSeriesUseCase) CreateSeries(organizationID uint, req *CreateSeriesRequest) (*CreateSeriesResponse, error) {
+	err := ensureMember(uc.organizationRepo, organizationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// メールアドレスを確認していないユーザーの予約は作成できない
+	err = ensureEmailVerified(uc.userRepo, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := uc.resourceRepo.FindByID(organizationID, req.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	series, dates, err := domain.NewReservationSeries(
+		req.UserID, resource.ID, req.RRule, req.StartDate, req.StartTime, req.EndTime, req.Quantity,
+	)
+	if err != nil {
+		return nil, err
+	}
+	series.OrganizationID = resource.OrganizationID
+
+	err = uc.seriesRepo.Create(series)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &CreateSeriesResponse{
+		Series:       series,
+		Reservations: make([]*domain.Reservation, 0, len(dates)),
+		Failures:     make([]*OccurrenceFailure, 0),
+	}
+
+	for _, date := range dates {
+		reservation, err := uc.createOccurrence(series, resource, date)
+		switch err {
+		case nil:
+			resp.Reservations = append(resp.Reservations, reservation)
+		case domain.ErrCapacityExceeded, domain.ErrSlotNotInSchedule, domain.ErrInvalidTimeRange, domain.ErrBlackoutDate:
+			resp.Failures = append(resp.Failures, &OccurrenceFailure{
+				Date:   date.Format("2006-01-02"),
+				Reason: err.Error(),
+			})
+		default:
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (uc *ReservationSeriesUseCase) createOccurrence(series *domain.ReservationSeries, resource *domain.Resource, date time.Time) (*domain.Reservation, error) {
+	timeSlot, err := resource.NewTimeSlot(date, series.StartTime, series.EndTime)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureNotBlackedOut(uc.blackoutRepo, resource, timeSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	reservation, err := domain.NewReservation(series.UserID, resource.ID, timeSlot, series.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	reservation.OrganizationID = series.OrganizationID
+	reservation.SeriesID = &series.ID
+	if resource.RequiresApproval {
+		reservation.RequireApproval()
+	}
+
+	err = uc.reservationRepo.CreateIfAvailable(reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	return reservation, nil
+}
+
+// SeriesResponse 繰り返し予約と各回の予約
+type SeriesResponse struct {
+	Series       *domain.ReservationSeries `json:"series"`
+	Reservations []*domain.Reservation     `json:"reservations"`
+}
+
+// GetSeries 繰り返し予約と各回の予約を取得
+// 予約者本人、管理者、リソースを担当するスタッフのみ取得できる
+func (uc *ReservationSeriesUseCase) GetSeries(id uint, principal *domain.Principal) (*SeriesResponse, error) {
+	series, err := uc.seriesRepo.FindByID(principal.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.UserID != principal.UserID && !principal.IsAdmin() {
+		resource, err := uc.resourceRepo.FindByID(series.OrganizationID, series.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.CanManage(resource) {
+			return nil, domain.ErrUnauthorized
+		}
+	}
+
+	reservations, err := uc.reservationRepo.FindBySeriesID(series.OrganizationID, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeriesResponse{
+		Series:       series,
+		Reservations: reservations,
+	}, nil
+}
+
+// CancelSeriesRequest 繰り返し予約キャンセルリクエスト
+type CancelSeriesRequest struct {
+	SeriesID      uint                     `json:"series_id"`
+	ReservationID uint                     `json:"reservation_id"`
+	UserID        uint                     `json:"user_id"`
+	Scope         domain.SeriesCancelScope `json:"scope"`
+}
+
+// CancelSeries 1回のみ・指定回以降・シリーズ全体のいずれかをキャンセルし、キャンセルした予約を返す
+func (uc *ReservationSeriesUseCase) CancelSeries(organizationID uint, req *CancelSeriesRequest) ([]*domain.Reservation, error) {
+	series, err := uc.seriesRepo.FindByID(organizationID, req.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	if series.UserID != req.UserID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	resource, err := uc.resourceRepo.FindByID(series.OrganizationID, series.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := uc.reservationRepo.FindBySeriesID(series.OrganizationID, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var target *domain.Reservation
+	for _, occurrence := range occurrences {
+		if occurrence.ID == req.ReservationID {
+			target = occurrence
+			break
+		}
+	}
+
+	now := time.Now()
+	cancelled, err := series.OccurrencesToCancel(occurrences, target, req.Scope, now, resource.CancellationPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := make([]domain.ReservationStatus, len(cancelled))
+	for i, reservation := range cancelled {
+		previous[i] = reservation.Status
+		if err := reservation.Cancel(now, resource.CancellationPolicy); err != nil {
+			return nil, err
+		}
+	}
+
+	// 読み込んだ後にチェックイン・期限切れ・個別のキャンセルなどで状態が変わった回は上書きせず、結果からも除く
+	cancelled, err = uc.reservationRepo.UpdateAllIfStatus(cancelled, previous)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, reservation := range cancelled {
+		promoteWaitlist(uc.waitlistRepo, reservation.OrganizationID, reservation.ResourceID, reservation.TimeSlot)
+	}
+
+	return cancelled, nil
+}
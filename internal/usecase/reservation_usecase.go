@@ -1,57 +1,121 @@
 package usecase
 
 import (
+	"time"
+
 	"reservation-system/internal/domain"
 	"reservation-system/internal/infrastructure/db"
 	"reservation-system/internal/repository"
 )
 
 type ReservationUseCase struct {
-	reservationRepo repository.ReservationRepository
-	userRepo        repository.UserRepository
+	reservationRepo  repository.ReservationRepository
+	resourceRepo     repository.ResourceRepository
+	waitlistRepo     repository.WaitlistRepository
+	blackoutRepo     repository.BlackoutRepository
+	organizationRepo repository.OrganizationRepository
+	userRepo         repository.UserRepository
+	holdTTL          time.Duration
+	noShowGrace      time.Duration
 }
 
 func NewReservationUseCase() *ReservationUseCase {
 	return &ReservationUseCase{
-		reservationRepo: db.NewReservationRepository(),
-		userRepo:        db.NewUserRepository(),
+		reservationRepo:  db.NewReservationRepository(),
+		resourceRepo:     db.NewResourceRepository(),
+		waitlistRepo:     db.NewWaitlistRepository(),
+		blackoutRepo:     db.NewBlackoutRepository(),
+		organizationRepo: db.NewOrganizationRepository(),
+		userRepo:         db.NewUserRepository(),
+		holdTTL:          HoldTTL(),
+		noShowGrace:      NoShowGrace(),
+	}
+}
+
+// SlotRequest 予約する時間枠
+// StartAt/EndAt（オフセット付きの日時）を指定した場合はそれを優先し、
+// それ以外はリソースのタイムゾーンでのローカル日付・時刻 Date/StartTime/EndTime として扱う
+// EndDate を指定すると終了日時は EndDate の EndTime となり、複数日にわたる時間枠になる
+type SlotRequest struct {
+	StartAt   time.Time `json:"start_at"`
+	EndAt     time.Time `json:"end_at"`
+	Date      time.Time `json:"date"`
+	EndDate   time.Time `json:"end_date"`
+	StartTime string    `json:"start_time"`
+	EndTime   string    `json:"end_time"`
+}
+
+// timeSlotFor リソースの時間枠に変換
+func (s *SlotRequest) timeSlotFor(resource *domain.Resource) (*domain.TimeSlot, error) {
+	if !s.StartAt.IsZero() || !s.EndAt.IsZero() {
+		return resource.TimeSlotAt(s.StartAt, s.EndAt)
 	}
+	if !s.EndDate.IsZero() {
+		return resource.NewTimeSpan(s.Date, s.StartTime, s.EndDate, s.EndTime)
+	}
+	return resource.NewTimeSlot(s.Date, s.StartTime, s.EndTime)
 }
 
+// CreateReservationRequest 予約作成リクエスト
+// JoinWaitlist が true の場合、満席ならキャンセル待ちへ登録する
 type CreateReservationRequest struct {
-	UserID   uint             `json:"user_id"`
-	TimeSlot *domain.TimeSlot `json:"time_slot"`
+	SlotRequest
+	UserID       uint `json:"user_id"`
+	ResourceID   uint `json:"resource_id"`
+	Quantity     int  `json:"quantity"`
+	JoinWaitlist bool `json:"join_waitlist"`
 }
 
+// CreateReservationResponse 予約作成レスポンス
+// 満席でキャンセル待ちに登録した場合は Reservation の代わりに Waitlist を返す
 type CreateReservationResponse struct {
-	Reservation *domain.Reservation `json:"reservation"`
+	Reservation *domain.Reservation `json:"reservation,omitempty"`
+	Waitlist    *WaitlistResponse   `json:"waitlist,omitempty"`
 }
 
-func (uc *ReservationUseCase) CreateReservation(req *CreateReservationRequest) (*CreateReservationResponse, error) {
-	_, err := uc.userRepo.FindByID(req.UserID)
+// CreateReservation 組織のリソースに予約を作成（予約者は組織のメンバーで、メールアドレスを確認済みのユーザーに限る）
+func (uc *ReservationUseCase) CreateReservation(organizationID uint, req *CreateReservationRequest) (*CreateReservationResponse, error) {
+	err := ensureMember(uc.organizationRepo, organizationID, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	// メールアドレスを確認していないユーザーの予約は作成できない
+	err = ensureEmailVerified(uc.userRepo, req.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := uc.resourceRepo.FindByID(organizationID, req.ResourceID)
 	if err != nil {
-		return nil, domain.ErrUserNotFound
+		return nil, err
 	}
 
-	count, err := uc.reservationRepo.CountByDateAndTime(
-		req.TimeSlot.Date.Format("2006-01-02"),
-		req.TimeSlot.StartTime,
-		req.TimeSlot.EndTime,
-	)
+	// 定員とタイムゾーンはリクエストではなくリソースから決定する
+	timeSlot, err := req.timeSlotFor(resource)
 	if err != nil {
 		return nil, err
 	}
 
-	if !req.TimeSlot.IsAvailable(count) {
-		return nil, domain.ErrCapacityExceeded
+	err = ensureNotBlackedOut(uc.blackoutRepo, resource, timeSlot)
+	if err != nil {
+		return nil, err
 	}
 
-	reservation, err := domain.NewReservation(req.UserID, req.TimeSlot)
+	reservation, err := domain.NewReservation(req.UserID, resource.ID, timeSlot, req.Quantity)
 	if err != nil {
 		return nil, err
 	}
+	reservation.OrganizationID = resource.OrganizationID
+	if resource.RequiresApproval {
+		reservation.RequireApproval()
+	}
 
-	err = uc.reservationRepo.Create(reservation)
+	// 定員チェックと作成はリポジトリ内で同一トランザクションとして行う
+	err = uc.reservationRepo.CreateIfAvailable(reservation)
+	if err == domain.ErrCapacityExceeded && req.JoinWaitlist {
+		return uc.joinWaitlist(req, resource, timeSlot)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -61,12 +125,115 @@ func (uc *ReservationUseCase) CreateReservation(req *CreateReservationRequest) (
 	}, nil
 }
 
-func (uc *ReservationUseCase) GetReservation(id uint) (*domain.Reservation, error) {
-	return uc.reservationRepo.FindByID(id)
+func (uc *ReservationUseCase) joinWaitlist(req *CreateReservationRequest, resource *domain.Resource, timeSlot *domain.TimeSlot) (*CreateReservationResponse, error) {
+	entry, err := domain.NewWaitlistEntry(req.UserID, resource.ID, timeSlot, req.Quantity)
+	if err != nil {
+		return nil, err
+	}
+	entry.OrganizationID = resource.OrganizationID
+
+	err = uc.waitlistRepo.Create(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// 登録までの間に空きが出ていればすぐに繰り上げる
+	promoteWaitlist(uc.waitlistRepo, entry.OrganizationID, entry.ResourceID, entry.TimeSlot)
+
+	entry, err = uc.waitlistRepo.FindByID(entry.OrganizationID, entry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	waitlist, err := waitlistResponse(uc.waitlistRepo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateReservationResponse{
+		Waitlist: waitlist,
+	}, nil
 }
 
-func (uc *ReservationUseCase) GetUserReservations(userID uint) ([]*domain.Reservation, error) {
-	return uc.reservationRepo.FindByUserID(userID)
+// GetReservation 予約を取得
+// 利用者は自分の予約のみ、スタッフは担当リソースの予約も、管理者はすべての予約を取得できる
+func (uc *ReservationUseCase) GetReservation(id uint, principal *domain.Principal) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.FindByID(principal.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.UserID == principal.UserID || principal.IsAdmin() {
+		return reservation, nil
+	}
+
+	resource, err := uc.resourceRepo.FindByID(reservation.OrganizationID, reservation.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !principal.CanView(reservation, resource) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return reservation, nil
+}
+
+// GetUserReservations ユーザーの予約一覧を取得
+// 他のユーザーの予約は、管理者はすべて、スタッフは担当リソースのものだけ取得できる
+func (uc *ReservationUseCase) GetUserReservations(userID uint, principal *domain.Principal) ([]*domain.Reservation, error) {
+	if userID != principal.UserID && !principal.HasRole(domain.RoleAdmin, domain.RoleStaff) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	reservations, err := uc.reservationRepo.FindByUserID(principal.OrganizationID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if userID == principal.UserID || principal.IsAdmin() {
+		return reservations, nil
+	}
+
+	resourceIDs, err := uc.resourceRepo.FindIDsByStaff(principal.OrganizationID, principal.UserID)
+	if err != nil {
+		return nil, err
+	}
+	staffed := make(map[uint]bool, len(resourceIDs))
+	for _, id := range resourceIDs {
+		staffed[id] = true
+	}
+
+	visible := make([]*domain.Reservation, 0, len(reservations))
+	for _, reservation := range reservations {
+		if staffed[reservation.ResourceID] {
+			visible = append(visible, reservation)
+		}
+	}
+	return visible, nil
+}
+
+// GetResourceReservations リソースの予約一覧を取得（担当スタッフと管理者のみ）
+func (uc *ReservationUseCase) GetResourceReservations(resourceID uint, principal *domain.Principal) ([]*domain.Reservation, error) {
+	resource, err := uc.resourceRepo.FindByID(principal.OrganizationID, resourceID)
+	if err != nil {
+		return nil, err
+	}
+	if !principal.CanManage(resource) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return uc.reservationRepo.FindByResourceID(resource.OrganizationID, resource.ID)
+}
+
+// authorizeStaff 予約先リソースのスタッフ業務を行えるかチェック
+func (uc *ReservationUseCase) authorizeStaff(reservation *domain.Reservation, principal *domain.Principal) error {
+	resource, err := uc.resourceRepo.FindByID(reservation.OrganizationID, reservation.ResourceID)
+	if err != nil {
+		return err
+	}
+	if !principal.CanManage(resource) {
+		return domain.ErrUnauthorized
+	}
+	return nil
 }
 
 type ConfirmReservationRequest struct {
@@ -74,8 +241,8 @@ type ConfirmReservationRequest struct {
 	UserID        uint `json:"user_id"`
 }
 
-func (uc *ReservationUseCase) ConfirmReservation(req *ConfirmReservationRequest) error {
-	reservation, err := uc.reservationRepo.FindByID(req.ReservationID)
+func (uc *ReservationUseCase) ConfirmReservation(organizationID uint, req *ConfirmReservationRequest) error {
+	reservation, err := uc.reservationRepo.FindByID(organizationID, req.ReservationID)
 	if err != nil {
 		return err
 	}
@@ -84,16 +251,32 @@ func (uc *ReservationUseCase) ConfirmReservation(req *ConfirmReservationRequest)
 		return domain.ErrUnauthorized
 	}
 
-	err = reservation.Confirm()
+	// スイープ前に保持期限を過ぎた仮予約は確定させずに期限切れにする
+	if reservation.IsHoldExpired(time.Now(), uc.holdTTL) {
+		if _, err := uc.expire(reservation, time.Now()); err != nil {
+			return err
+		}
+		return domain.ErrReservationExpired
+	}
+
+	err = reservation.Confirm(time.Now())
 	if err != nil {
 		return err
 	}
 
-	return uc.reservationRepo.Update(reservation)
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusPending)
+	if err != nil {
+		return err
+	}
+	// 確定の間に期限切れのスイープが先に更新していた場合
+	if !updated {
+		return domain.ErrReservationExpired
+	}
+	return nil
 }
 
-func (uc *ReservationUseCase) CancelReservation(reservationID, userID uint) error {
-	reservation, err := uc.reservationRepo.FindByID(reservationID)
+func (uc *ReservationUseCase) CancelReservation(organizationID, reservationID, userID uint) error {
+	reservation, err := uc.reservationRepo.FindByID(organizationID, reservationID)
 	if err != nil {
 		return err
 	}
@@ -102,10 +285,269 @@ func (uc *ReservationUseCase) CancelReservation(reservationID, userID uint) erro
 		return domain.ErrUnauthorized
 	}
 
-	err = reservation.Cancel()
+	resource, err := uc.resourceRepo.FindByID(reservation.OrganizationID, reservation.ResourceID)
+	if err != nil {
+		return err
+	}
+
+	previous := reservation.Status
+	err = reservation.Cancel(time.Now(), resource.CancellationPolicy)
+	if err != nil {
+		return err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, previous)
 	if err != nil {
 		return err
 	}
+	// キャンセルの間に期限切れ・チェックインなどで状態が変わっていた場合
+	if !updated {
+		return domain.ErrInvalidStatusTransition
+	}
+
+	promoteWaitlist(uc.waitlistRepo, reservation.OrganizationID, reservation.ResourceID, reservation.TimeSlot)
+	return nil
+}
 
-	return uc.reservationRepo.Update(reservation)
+// RescheduleReservationRequest 予約変更リクエスト
+type RescheduleReservationRequest struct {
+	SlotRequest
+	ReservationID uint `json:"reservation_id"`
+	UserID        uint `json:"user_id"`
+}
+
+// RescheduleReservation 予約を同じリソースの別の時間枠へ移動
+// 移動先が満席の場合は元の予約をそのまま残して ErrCapacityExceeded を返す
+func (uc *ReservationUseCase) RescheduleReservation(organizationID uint, req *RescheduleReservationRequest) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.FindByID(organizationID, req.ReservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reservation.UserID != req.UserID {
+		return nil, domain.ErrUnauthorized
+	}
+
+	resource, err := uc.resourceRepo.FindByID(reservation.OrganizationID, reservation.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	timeSlot, err := req.timeSlotFor(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ensureNotBlackedOut(uc.blackoutRepo, resource, timeSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	previous := reservation.TimeSlot
+	err = reservation.Reschedule(timeSlot)
+	if err != nil {
+		return nil, err
+	}
+
+	// 移動先の定員チェックと更新はリポジトリ内で同一トランザクションとして行う
+	err = uc.reservationRepo.UpdateIfAvailable(reservation)
+	if err != nil {
+		return nil, err
+	}
+
+	promoteWaitlist(uc.waitlistRepo, reservation.OrganizationID, reservation.ResourceID, previous)
+	return reservation, nil
+}
+
+// ExpirePendingReservations 保持期限を過ぎた仮予約を期限切れにして定員を解放
+// 複数レプリカで同時に実行しても、各予約を期限切れにするのは1レプリカのみ
+func (uc *ReservationUseCase) ExpirePendingReservations(now time.Time) error {
+	if uc.holdTTL <= 0 {
+		return nil
+	}
+
+	reservations, err := uc.reservationRepo.FindPendingCreatedBefore(now.Add(-uc.holdTTL))
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		if _, err := uc.expire(reservation, now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expire 仮予約を期限切れにし、空いた枠をキャンセル待ちへ繰り上げる
+// 他のレプリカが先に処理していた場合は false を返す
+func (uc *ReservationUseCase) expire(reservation *domain.Reservation, now time.Time) (bool, error) {
+	if err := reservation.Expire(now, uc.holdTTL); err != nil {
+		return false, err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusPending)
+	if err != nil || !updated {
+		return false, err
+	}
+
+	promoteWaitlist(uc.waitlistRepo, reservation.OrganizationID, reservation.ResourceID, reservation.TimeSlot)
+	return true, nil
+}
+
+// ApproveReservation 承認待ちの予約をリソースの承認者が承認して確定
+func (uc *ReservationUseCase) ApproveReservation(organizationID, reservationID, approverID uint) (*domain.Reservation, error) {
+	reservation, err := uc.findForApprover(organizationID, reservationID, approverID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reservation.Approve(approverID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	// 承認の間に利用者がキャンセルしていた場合
+	if !updated {
+		return nil, domain.ErrReservationNotPending
+	}
+
+	return reservation, nil
+}
+
+// RejectReservationRequest 予約却下リクエスト
+type RejectReservationRequest struct {
+	ReservationID uint   `json:"reservation_id"`
+	ApproverID    uint   `json:"user_id"`
+	Reason        string `json:"reason"`
+}
+
+// RejectReservation 承認待ちの予約をリソースの承認者が理由を付けて却下
+// 空いた枠はキャンセル待ちへ繰り上げる
+func (uc *ReservationUseCase) RejectReservation(organizationID uint, req *RejectReservationRequest) (*domain.Reservation, error) {
+	reservation, err := uc.findForApprover(organizationID, req.ReservationID, req.ApproverID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reservation.Reject(req.ApproverID, req.Reason, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusPending)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, domain.ErrReservationNotPending
+	}
+
+	promoteWaitlist(uc.waitlistRepo, reservation.OrganizationID, reservation.ResourceID, reservation.TimeSlot)
+	return reservation, nil
+}
+
+// GetApprovalQueue 組織内で approverID が承認者になっているリソースの承認待ち予約を取得
+func (uc *ReservationUseCase) GetApprovalQueue(organizationID, approverID uint) ([]*domain.Reservation, error) {
+	return uc.reservationRepo.FindAwaitingApproval(organizationID, approverID)
+}
+
+// findForApprover 予約を取得し、approverID が予約先リソースの承認者かチェック
+func (uc *ReservationUseCase) findForApprover(organizationID, reservationID, approverID uint) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.FindByID(organizationID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	resource, err := uc.resourceRepo.FindByID(reservation.OrganizationID, reservation.ResourceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !resource.CanApprove(approverID) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return reservation, nil
+}
+
+// CheckInReservation 来訪した利用者の予約をチェックイン（担当スタッフ・管理者の操作）
+func (uc *ReservationUseCase) CheckInReservation(reservationID uint, principal *domain.Principal) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.FindByID(principal.OrganizationID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.authorizeStaff(reservation, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reservation.CheckIn(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusConfirmed)
+	if err != nil {
+		return nil, err
+	}
+	// 無断キャンセル判定のジョブが先に更新していた場合
+	if !updated {
+		return nil, domain.ErrInvalidStatusTransition
+	}
+
+	return reservation, nil
+}
+
+// CompleteReservation チェックイン済みの予約を利用完了にする（担当スタッフ・管理者の操作）
+func (uc *ReservationUseCase) CompleteReservation(reservationID uint, principal *domain.Principal) (*domain.Reservation, error) {
+	reservation, err := uc.reservationRepo.FindByID(principal.OrganizationID, reservationID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.authorizeStaff(reservation, principal)
+	if err != nil {
+		return nil, err
+	}
+
+	err = reservation.Complete(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusCheckedIn)
+	if err != nil {
+		return nil, err
+	}
+	// 同時に利用完了にされた場合、成功するのは1リクエストのみ
+	if !updated {
+		return nil, domain.ErrInvalidStatusTransition
+	}
+
+	return reservation, nil
+}
+
+// MarkNoShows 開始から猶予時間を過ぎてもチェックインのない確定済み予約を無断キャンセルにする
+// チェックインと競合した場合はチェックインを優先する
+func (uc *ReservationUseCase) MarkNoShows(now time.Time) error {
+	reservations, err := uc.reservationRepo.FindConfirmedStartedBefore(now.Add(-uc.noShowGrace))
+	if err != nil {
+		return err
+	}
+
+	for _, reservation := range reservations {
+		if err := reservation.MarkNoShow(now, uc.noShowGrace); err != nil {
+			continue
+		}
+		if _, err := uc.reservationRepo.UpdateIfStatus(reservation, domain.StatusConfirmed); err != nil {
+			return err
+		}
+	}
+	return nil
 }
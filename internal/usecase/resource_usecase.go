@@ -0,0 +1,265 @@
+package usecase
+
+import (
+	"time"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// ResourceUseCase リソースユースケース
+type ResourceUseCase struct {
+	resourceRepo     repository.ResourceRepository
+	organizationRepo repository.OrganizationRepository
+}
+
+// NewResourceUseCase リソースユースケースを作成
+func NewResourceUseCase() *ResourceUseCase {
+	return &ResourceUseCase{
+		resourceRepo:     db.NewResourceRepository(),
+		organizationRepo: db.NewOrganizationRepository(),
+	}
+}
+
+// ResourceRequest リソース作成・更新リクエスト
+type ResourceRequest struct {
+	Name                  string              `json:"name"`
+	Type                  domain.ResourceType `json:"type"`
+	Capacity              int                 `json:"capacity"`
+	Description           string              `json:"description"`
+	TimeZone              string              `json:"time_zone"`
+	SetupBufferMinutes    int                 `json:"setup_buffer_minutes"`
+	TeardownBufferMinutes int                 `json:"teardown_buffer_minutes"`
+}
+
+// CreateResource 組織にリソースを作成
+func (uc *ResourceUseCase) CreateResource(organizationID uint, req *ResourceRequest) (*domain.Resource, error) {
+	resource, err := domain.NewResource(req.Name, req.Type, req.Capacity, req.Description)
+	if err != nil {
+		return nil, err
+	}
+	resource.OrganizationID = organizationID
+
+	err = resource.SetTimeZone(req.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resource.SetBuffers(req.SetupBufferMinutes, req.TeardownBufferMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.Create(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// GetResource リソースを取得
+func (uc *ResourceUseCase) GetResource(organizationID, id uint) (*domain.Resource, error) {
+	return uc.resourceRepo.FindByID(organizationID, id)
+}
+
+// ListResources 組織のリソース一覧を取得
+func (uc *ResourceUseCase) ListResources(organizationID uint) ([]*domain.Resource, error) {
+	return uc.resourceRepo.FindAll(organizationID)
+}
+
+// UpdateResource リソースを更新
+func (uc *ResourceUseCase) UpdateResource(organizationID, id uint, req *ResourceRequest) (*domain.Resource, error) {
+	resource, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resource.Update(req.Name, req.Type, req.Capacity, req.Description)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resource.SetTimeZone(req.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+
+	err = resource.SetBuffers(req.SetupBufferMinutes, req.TeardownBufferMinutes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.Update(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// OpeningHoursRequest 営業時間リクエスト
+type OpeningHoursRequest struct {
+	Weekday     int    `json:"weekday"`
+	OpenTime    string `json:"open_time"`
+	CloseTime   string `json:"close_time"`
+	SlotMinutes int    `json:"slot_minutes"`
+}
+
+// UpdateScheduleRequest 公開スケジュール更新リクエスト
+type UpdateScheduleRequest struct {
+	OpeningHours []OpeningHoursRequest `json:"opening_hours"`
+}
+
+// UpdateSchedule リソースの公開スケジュールを置き換え
+func (uc *ResourceUseCase) UpdateSchedule(organizationID, id uint, req *UpdateScheduleRequest) (*domain.Resource, error) {
+	resource, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := make([]*domain.OpeningHours, 0, len(req.OpeningHours))
+	for _, h := range req.OpeningHours {
+		openingHours, err := domain.NewOpeningHours(time.Weekday(h.Weekday), h.OpenTime, h.CloseTime, h.SlotMinutes)
+		if err != nil {
+			return nil, err
+		}
+		hours = append(hours, openingHours)
+	}
+
+	err = resource.SetSchedule(hours)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.ReplaceSchedule(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// CancellationTierRequest キャンセル料段階リクエスト
+type CancellationTierRequest struct {
+	MinutesBefore int `json:"minutes_before"`
+	FeePercent    int `json:"fee_percent"`
+}
+
+// UpdateCancellationPolicyRequest キャンセルポリシー更新リクエスト
+type UpdateCancellationPolicyRequest struct {
+	Tiers []CancellationTierRequest `json:"tiers"`
+}
+
+// UpdateCancellationPolicy リソースのキャンセルポリシーを置き換え
+func (uc *ResourceUseCase) UpdateCancellationPolicy(organizationID, id uint, req *UpdateCancellationPolicyRequest) (*domain.Resource, error) {
+	resource, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	tiers := make([]*domain.CancellationTier, 0, len(req.Tiers))
+	for _, t := range req.Tiers {
+		tier, err := domain.NewCancellationTier(t.MinutesBefore, t.FeePercent)
+		if err != nil {
+			return nil, err
+		}
+		tiers = append(tiers, tier)
+	}
+
+	err = resource.SetCancellationPolicy(tiers)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.ReplaceCancellationPolicy(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// UpdateApprovalRequest 承認設定更新リクエスト
+type UpdateApprovalRequest struct {
+	RequiresApproval bool   `json:"requires_approval"`
+	ApproverIDs      []uint `json:"approver_ids"`
+}
+
+// UpdateApproval リソースの予約に承認が必要かどうかと承認者を置き換え
+// 承認を不要にしても、すでに承認待ちの予約は引き続き承認者が承認・却下する
+func (uc *ResourceUseCase) UpdateApproval(organizationID, id uint, req *UpdateApprovalRequest) (*domain.Resource, error) {
+	resource, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 承認者は組織に所属し、その組織でスタッフ以上の権限を持つユーザーに限る
+	for _, userID := range req.ApproverIDs {
+		membership, err := findMembership(uc.organizationRepo, organizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !membership.Role.HasStaffPrivileges() {
+			return nil, domain.ErrInvalidApprovers
+		}
+	}
+
+	err = resource.SetApprovalRequirement(req.RequiresApproval, req.ApproverIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.ReplaceApprovers(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// UpdateStaffRequest 担当スタッフ更新リクエスト
+type UpdateStaffRequest struct {
+	StaffIDs []uint `json:"staff_ids"`
+}
+
+// UpdateStaff リソースの担当スタッフを置き換え
+func (uc *ResourceUseCase) UpdateStaff(organizationID, id uint, req *UpdateStaffRequest) (*domain.Resource, error) {
+	resource, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, userID := range req.StaffIDs {
+		membership, err := findMembership(uc.organizationRepo, organizationID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if membership.Role != domain.RoleStaff {
+			return nil, domain.ErrInvalidStaff
+		}
+	}
+
+	err = resource.SetStaff(req.StaffIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.resourceRepo.ReplaceStaff(resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource, nil
+}
+
+// DeleteResource リソースを削除
+func (uc *ResourceUseCase) DeleteResource(organizationID, id uint) error {
+	_, err := uc.resourceRepo.FindByID(organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	return uc.resourceRepo.Delete(organizationID, id)
+}
@@ -1,21 +1,35 @@
 package usecase
 
 import (
+	"time"
+
 	"reservation-system/internal/domain"
 	"reservation-system/internal/infrastructure/db"
-	"reservation-system/internal/infrastructure/jwt"
+	"reservation-system/internal/infrastructure/mail"
 	"reservation-system/internal/repository"
 )
 
 // UserUseCase ユーザーユースケース
 type UserUseCase struct {
-	userRepo repository.UserRepository
+	userRepo              repository.UserRepository
+	organizationRepo      repository.OrganizationRepository
+	refreshTokenRepo      repository.RefreshTokenRepository
+	emailVerificationRepo repository.EmailVerificationRepository
+	mfaChallengeRepo      repository.MFAChallengeRepository
+	mailSender            mail.Sender
+	loginGuard            *loginGuard
 }
 
 // NewUserUseCase ユーザーユースケースを作成
 func NewUserUseCase() *UserUseCase {
 	return &UserUseCase{
-		userRepo: db.NewUserRepository(),
+		userRepo:              db.NewUserRepository(),
+		organizationRepo:      db.NewOrganizationRepository(),
+		refreshTokenRepo:      db.NewRefreshTokenRepository(),
+		emailVerificationRepo: db.NewEmailVerificationRepository(),
+		mfaChallengeRepo:      db.NewMFAChallengeRepository(),
+		mailSender:            mail.NewSender(),
+		loginGuard:            newLoginGuard(),
 	}
 }
 
@@ -26,7 +40,7 @@ type CreateUserRequest struct {
 	Name     string `json:"name"`
 }
 
-// CreateUser ユーザーを作成
+// CreateUser ユーザーを作成し、確認メールを送る
 func (uc *UserUseCase) CreateUser(req *CreateUserRequest) (*domain.User, error) {
 	exists, err := uc.userRepo.Exists(req.Email)
 	if err != nil {
@@ -46,50 +60,145 @@ func (uc *UserUseCase) CreateUser(req *CreateUserRequest) (*domain.User, error)
 		return nil, err
 	}
 
+	_, _, err = joinDefaultOrganization(uc.organizationRepo, user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sendVerification(uc.emailVerificationRepo, uc.mailSender, user, time.Now(), EmailVerificationTTL())
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UnlockUser 組織のメンバーのログイン失敗回数をリセットしてロックを解除
+func (uc *UserUseCase) UnlockUser(organizationID, id uint) (*domain.User, error) {
+	err := ensureMember(uc.organizationRepo, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.ClearFailedLogins()
+	err = uc.userRepo.UpdateLockout(user)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
 
-// GetUser ユーザーを取得
-func (uc *UserUseCase) GetUser(id uint) (*domain.User, error) {
-	return uc.userRepo.FindByID(id)
+// GetUser 組織のメンバーとその組織での権限を取得（他の組織のユーザーは ErrUserNotFound）
+func (uc *UserUseCase) GetUser(organizationID, id uint) (*MemberResponse, error) {
+	membership, err := findMembership(uc.organizationRepo, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberResponse{User: user, Role: membership.Role}, nil
+}
+
+// UpdateRoleRequest 権限変更リクエスト
+type UpdateRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// UpdateRole 組織のメンバーのその組織での権限を変更（他の組織での権限は変わらず、発行済みのトークンには次回ログインから反映される）
+func (uc *UserUseCase) UpdateRole(organizationID, id uint, req *UpdateRoleRequest) (*MemberResponse, error) {
+	role, err := domain.ParseRole(req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	membership, err := findMembership(uc.organizationRepo, organizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := uc.userRepo.FindByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = membership.SetRole(role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = uc.organizationRepo.UpdateMemberRole(membership)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberResponse{User: user, Role: membership.Role}, nil
 }
 
 // LoginRequest ログインリクエスト
+// OrganizationID を省略すると最初に所属した組織にログインする
+// IPAddress はハンドラーが設定する接続元で、IP アドレスごとの失敗回数の記録に使う
 type LoginRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Email          string `json:"email"`
+	Password       string `json:"password"`
+	OrganizationID uint   `json:"organization_id"`
+	IPAddress      string `json:"-"`
 }
 
 // LoginResponse ログインレスポンス
+// 2段階認証を有効にしたユーザーにはトークンの代わりに MFARequired と MFAToken を返す
 type LoginResponse struct {
-	Token string       `json:"token"`
-	User  *domain.User `json:"user"`
+	Token        string               `json:"token,omitempty"`
+	RefreshToken string               `json:"refresh_token,omitempty"`
+	User         *MemberResponse      `json:"user,omitempty"`
+	Organization *domain.Organization `json:"organization,omitempty"`
+	MFARequired  bool                 `json:"mfa_required"`
+	MFAToken     string               `json:"mfa_token,omitempty"`
 }
 
-// Login ログイン
+// Login ログイン（2段階認証が有効なユーザーには MFA チャレンジを返す）
 func (uc *UserUseCase) Login(req *LoginRequest) (*LoginResponse, error) {
-	user, err := uc.userRepo.FindByEmail(req.Email)
+	user, err := uc.loginGuard.checkCredentials(req.Email, req.Password, req.IPAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	organization, membership, err := selectOrganization(uc.organizationRepo, user.ID, req.OrganizationID)
 	if err != nil {
-		return nil, domain.ErrInvalidCredentials
+		return nil, err
 	}
 
-	if err := user.CheckPassword(req.Password); err != nil {
-		return nil, domain.ErrInvalidCredentials
+	if user.IsTOTPEnabled() {
+		mfaToken, err := startMFAChallenge(uc.mfaChallengeRepo, user.ID, organization.ID)
+		if err != nil {
+			return nil, err
+		}
+		return &LoginResponse{MFARequired: true, MFAToken: mfaToken}, nil
+	}
+
+	err = uc.loginGuard.succeed(user)
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := jwt.GenerateToken(user.ID, user.Email)
+	token, refreshToken, err := issueTokens(uc.refreshTokenRepo, user, membership, "")
 	if err != nil {
 		return nil, err
 	}
 
 	return &LoginResponse{
-		Token: token,
-		User: &domain.User{
-			ID:        user.ID,
-			Email:     user.Email,
-			Name:      user.Name,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		Token:        token,
+		RefreshToken: refreshToken,
+		Organization: organization,
+		User:         newMemberResponse(user, membership),
 	}, nil
 }
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"log"
+
+	"reservation-system/internal/domain"
+	"reservation-system/internal/infrastructure/db"
+	"reservation-system/internal/repository"
+)
+
+// WaitlistUseCase キャンセル待ちユースケース
+type WaitlistUseCase struct {
+	waitlistRepo repository.WaitlistRepository
+	resourceRepo repository.ResourceRepository
+}
+
+// NewWaitlistUseCase キャンセル待ちユースケースを作成
+func NewWaitlistUseCase() *WaitlistUseCase {
+	return &WaitlistUseCase{
+		waitlistRepo: db.NewWaitlistRepository(),
+		resourceRepo: db.NewResourceRepository(),
+	}
+}
+
+// WaitlistResponse キャンセル待ちと現在の順番
+// 繰り上げ済み・取り下げ済みの場合 Position は 0
+type WaitlistResponse struct {
+	Entry    *domain.WaitlistEntry `json:"entry"`
+	Position int                   `json:"position"`
+}
+
+// GetEntry キャンセル待ちと順番を取得
+// 登録した本人、管理者、リソースを担当するスタッフのみ取得できる
+func (uc *WaitlistUseCase) GetEntry(id uint, principal *domain.Principal) (*WaitlistResponse, error) {
+	entry, err := uc.waitlistRepo.FindByID(principal.OrganizationID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.UserID != principal.UserID && !principal.IsAdmin() {
+		resource, err := uc.resourceRepo.FindByID(entry.OrganizationID, entry.ResourceID)
+		if err != nil {
+			return nil, err
+		}
+		if !principal.CanManage(resource) {
+			return nil, domain.ErrUnauthorized
+		}
+	}
+
+	return waitlistResponse(uc.waitlistRepo, entry)
+}
+
+// LeaveWaitlist キャンセル待ちを取り下げ
+func (uc *WaitlistUseCase) LeaveWaitlist(organizationID, id, userID uint) error {
+	entry, err := uc.waitlistRepo.FindByID(organizationID, id)
+	if err != nil {
+		return err
+	}
+
+	if entry.UserID != userID {
+		return domain.ErrUnauthorized
+	}
+
+	err = entry.Leave()
+	if err != nil {
+		return err
+	}
+
+	// 同時に繰り上げられていた場合は、確保した仮予約を残したまま取り下げにしない
+	left, err := uc.waitlistRepo.UpdateIfWaiting(entry)
+	if err != nil {
+		return err
+	}
+	if !left {
+		return domain.ErrWaitlistEntryNotWaiting
+	}
+	return nil
+}
+
+func waitlistResponse(waitlistRepo repository.WaitlistRepository, entry *domain.WaitlistEntry) (*WaitlistResponse, error) {
+	resp := &WaitlistResponse{Entry: entry}
+	if entry.Status != domain.WaitlistStatusWaiting {
+		return resp, nil
+	}
+
+	position, err := waitlistRepo.Position(entry)
+	if err != nil {
+		return nil, err
+	}
+	resp.Position = position
+	return resp, nil
+}
+
+// promoteWaitlist 空いた時間枠のキャンセル待ちを繰り上げ
+// 繰り上げの失敗で元の操作（キャンセル等）を失敗させないようログのみ出力する
+func promoteWaitlist(waitlistRepo repository.WaitlistRepository, organizationID, resourceID uint, timeSlot *domain.TimeSlot) {
+	promoted, err := waitlistRepo.PromoteWaiting(organizationID, resourceID, timeSlot)
+	if err != nil {
+		log.Printf("failed to promote waitlist for resource %d: %v", resourceID, err)
+		return
+	}
+	for _, entry := range promoted {
+		log.Printf("waitlist entry %d promoted to reservation %d", entry.ID, *entry.ReservationID)
+	}
+}
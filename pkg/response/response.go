@@ -38,6 +38,13 @@ func Created(w http.ResponseWriter, data interface{}) {
 	})
 }
 
+func Accepted(w http.ResponseWriter, data interface{}) {
+	WriteJSON(w, http.StatusAccepted, Response{
+		Success: true,
+		Data:    data,
+	})
+}
+
 func BadRequest(w http.ResponseWriter, message string) {
 	Error(w, http.StatusBadRequest, message)
 }
@@ -54,6 +61,10 @@ func NotFound(w http.ResponseWriter, message string) {
 	Error(w, http.StatusNotFound, message)
 }
 
+func Conflict(w http.ResponseWriter, message string) {
+	Error(w, http.StatusConflict, message)
+}
+
 func InternalServerError(w http.ResponseWriter, message string) {
 	Error(w, http.StatusInternalServerError, message)
 }